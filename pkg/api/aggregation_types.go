@@ -0,0 +1,14 @@
+package api
+
+// AggregationListKind is the kind of the aggregations reported by the API.
+const AggregationListKind = "AggregationList"
+
+// Aggregation is a group of resources sharing the values of the grouped fields,
+// e.g. all dinosaurs of a species. Min and Max hold the smallest and largest values
+// of the aggregated fields within the group. All maps are keyed by json field name.
+type Aggregation struct {
+	Group map[string]interface{}
+	Count int64
+	Min   map[string]interface{}
+	Max   map[string]interface{}
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IdempotencyKey holds the response of a request sent with an Idempotency-Key header,
+// the retries of the request with the same key get it instead of running the request again.
+// A key belongs to the user who sent it, for the method and path of the request.
+type IdempotencyKey struct {
+	Meta
+	Key         string
+	Username    string
+	Method      string
+	Path        string
+	RequestHash string // sha256 of the request body
+	StatusCode  int
+	Response    []byte
+	ExpiresAt   time.Time
+}
+
+func (k *IdempotencyKey) BeforeCreate(tx *gorm.DB) error {
+	k.ID = NewID()
+	return nil
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+// Labels are key/value pairs tagging a resource, they are stored in a JSONB column.
+// Keys and values follow the Kubernetes label syntax, see ValidateLabels.
+type Labels map[string]string
+
+// Labeled is embedded next to Meta by the kinds which can be labeled and selected
+// by label, e.g. with labelSelector=env=prod,tier in (web,api)
+type Labeled struct {
+	Labels Labels `gorm:"type:jsonb;not null;default:'{}'"`
+}
+
+// Value stores the labels as a JSON object, no labels are stored as an empty one
+func (l Labels) Value() (driver.Value, error) {
+	if l == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan loads the labels from their JSON object
+func (l *Labels) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*l = Labels{}
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("unable to scan %T into labels", value)
+	}
+	labels := Labels{}
+	if err := json.Unmarshal(b, &labels); err != nil {
+		return err
+	}
+	*l = labels
+	return nil
+}
+
+const (
+	labelNameMaxLength   = 63
+	labelPrefixMaxLength = 253
+)
+
+var (
+	labelNameRegex   = regexp.MustCompile(`^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$`)
+	labelPrefixRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// ValidateLabelKey checks a label key is an optional DNS subdomain prefix and a name,
+// e.g. example.com/tier, the name is at most 63 alphanumeric characters, '-', '_' or '.'
+func ValidateLabelKey(key string) error {
+	name := key
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		prefix := key[:i]
+		name = key[i+1:]
+		if len(prefix) > labelPrefixMaxLength || !labelPrefixRegex.MatchString(prefix) {
+			return fmt.Errorf("label key %q must have a DNS subdomain as prefix", key)
+		}
+	}
+	if len(name) > labelNameMaxLength || !labelNameRegex.MatchString(name) {
+		return fmt.Errorf("label key %q must be at most %d alphanumeric characters, '-', '_' or '.', starting and ending with an alphanumeric character",
+			key, labelNameMaxLength)
+	}
+	return nil
+}
+
+// ValidateLabelValue checks a label value is empty or at most 63 alphanumeric characters, '-', '_' or '.'
+func ValidateLabelValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > labelNameMaxLength || !labelNameRegex.MatchString(value) {
+		return fmt.Errorf("label value %q must be at most %d alphanumeric characters, '-', '_' or '.', starting and ending with an alphanumeric character",
+			value, labelNameMaxLength)
+	}
+	return nil
+}
+
+// ValidateLabels checks the keys and values of the labels of a resource
+func ValidateLabels(labels map[string]string) *errors.ServiceError {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	// report the same error whatever the order of the map
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := ValidateLabelKey(key); err != nil {
+			return errors.Validation("%s", err.Error()).WithDetails(errors.FieldViolation{
+				Field: "labels." + key, Rule: "label_key", Message: err.Error(),
+			})
+		}
+		if err := ValidateLabelValue(labels[key]); err != nil {
+			return errors.Validation("%s", err.Error()).WithDetails(errors.FieldViolation{
+				Field: "labels." + key, Rule: "label_value", Message: err.Error(),
+			})
+		}
+	}
+	return nil
+}
@@ -27,7 +27,11 @@ type Dinosaur struct {
 	Href      *string    `json:"href,omitempty"`
 	CreatedAt *time.Time `json:"created_at,omitempty"`
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
-	Species   string     `json:"species"`
+	Species   string     `json:"species" validate:"required,max=255"`
+	// Key/value pairs the dinosaur can be selected by with labelSelector
+	Labels *map[string]string `json:"labels,omitempty"`
+	// When the dinosaur was deleted, only set on the deleted dinosaurs admins list
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 type _Dinosaur Dinosaur
@@ -234,6 +238,70 @@ func (o *Dinosaur) SetSpecies(v string) {
 	o.Species = v
 }
 
+// GetLabels returns the Labels field value if set, zero value otherwise.
+func (o *Dinosaur) GetLabels() map[string]string {
+	if o == nil || IsNil(o.Labels) {
+		var ret map[string]string
+		return ret
+	}
+	return *o.Labels
+}
+
+// GetLabelsOk returns a tuple with the Labels field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Dinosaur) GetLabelsOk() (*map[string]string, bool) {
+	if o == nil || IsNil(o.Labels) {
+		return nil, false
+	}
+	return o.Labels, true
+}
+
+// HasLabels returns a boolean if a field has been set.
+func (o *Dinosaur) HasLabels() bool {
+	if o != nil && !IsNil(o.Labels) {
+		return true
+	}
+
+	return false
+}
+
+// SetLabels gets a reference to the given map[string]string and assigns it to the Labels field.
+func (o *Dinosaur) SetLabels(v map[string]string) {
+	o.Labels = &v
+}
+
+// GetDeletedAt returns the DeletedAt field value if set, zero value otherwise.
+func (o *Dinosaur) GetDeletedAt() time.Time {
+	if o == nil || IsNil(o.DeletedAt) {
+		var ret time.Time
+		return ret
+	}
+	return *o.DeletedAt
+}
+
+// GetDeletedAtOk returns a tuple with the DeletedAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Dinosaur) GetDeletedAtOk() (*time.Time, bool) {
+	if o == nil || IsNil(o.DeletedAt) {
+		return nil, false
+	}
+	return o.DeletedAt, true
+}
+
+// HasDeletedAt returns a boolean if a field has been set.
+func (o *Dinosaur) HasDeletedAt() bool {
+	if o != nil && !IsNil(o.DeletedAt) {
+		return true
+	}
+
+	return false
+}
+
+// SetDeletedAt gets a reference to the given time.Time and assigns it to the DeletedAt field.
+func (o *Dinosaur) SetDeletedAt(v time.Time) {
+	o.DeletedAt = &v
+}
+
 func (o Dinosaur) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -260,6 +328,12 @@ func (o Dinosaur) ToMap() (map[string]interface{}, error) {
 		toSerialize["updated_at"] = o.UpdatedAt
 	}
 	toSerialize["species"] = o.Species
+	if !IsNil(o.Labels) {
+		toSerialize["labels"] = o.Labels
+	}
+	if !IsNil(o.DeletedAt) {
+		toSerialize["deleted_at"] = o.DeletedAt
+	}
 	return toSerialize, nil
 }
 
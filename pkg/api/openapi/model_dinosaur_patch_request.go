@@ -19,7 +19,9 @@ var _ MappedNullable = &DinosaurPatchRequest{}
 
 // DinosaurPatchRequest struct for DinosaurPatchRequest
 type DinosaurPatchRequest struct {
-	Species *string `json:"species,omitempty"`
+	Species *string `json:"species,omitempty" validate:"max=255"`
+	// Key/value pairs the dinosaur can be selected by with labelSelector
+	Labels *map[string]string `json:"labels,omitempty"`
 }
 
 // NewDinosaurPatchRequest instantiates a new DinosaurPatchRequest object
@@ -71,6 +73,38 @@ func (o *DinosaurPatchRequest) SetSpecies(v string) {
 	o.Species = &v
 }
 
+// GetLabels returns the Labels field value if set, zero value otherwise.
+func (o *DinosaurPatchRequest) GetLabels() map[string]string {
+	if o == nil || IsNil(o.Labels) {
+		var ret map[string]string
+		return ret
+	}
+	return *o.Labels
+}
+
+// GetLabelsOk returns a tuple with the Labels field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *DinosaurPatchRequest) GetLabelsOk() (*map[string]string, bool) {
+	if o == nil || IsNil(o.Labels) {
+		return nil, false
+	}
+	return o.Labels, true
+}
+
+// HasLabels returns a boolean if a field has been set.
+func (o *DinosaurPatchRequest) HasLabels() bool {
+	if o != nil && !IsNil(o.Labels) {
+		return true
+	}
+
+	return false
+}
+
+// SetLabels gets a reference to the given map[string]string and assigns it to the Labels field.
+func (o *DinosaurPatchRequest) SetLabels(v map[string]string) {
+	o.Labels = &v
+}
+
 func (o DinosaurPatchRequest) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -84,6 +118,9 @@ func (o DinosaurPatchRequest) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Species) {
 		toSerialize["species"] = o.Species
 	}
+	if !IsNil(o.Labels) {
+		toSerialize["labels"] = o.Labels
+	}
 	return toSerialize, nil
 }
 
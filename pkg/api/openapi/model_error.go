@@ -28,6 +28,8 @@ type Error struct {
 	Code        *string    `json:"code,omitempty"`
 	Reason      *string    `json:"reason,omitempty"`
 	OperationId *string    `json:"operation_id,omitempty"`
+	// The fields of the request which failed validation, if any
+	Details []FieldViolation `json:"details,omitempty"`
 }
 
 // NewError instantiates a new Error object
@@ -303,6 +305,38 @@ func (o *Error) SetOperationId(v string) {
 	o.OperationId = &v
 }
 
+// GetDetails returns the Details field value if set, zero value otherwise.
+func (o *Error) GetDetails() []FieldViolation {
+	if o == nil || IsNil(o.Details) {
+		var ret []FieldViolation
+		return ret
+	}
+	return o.Details
+}
+
+// GetDetailsOk returns a tuple with the Details field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Error) GetDetailsOk() ([]FieldViolation, bool) {
+	if o == nil || IsNil(o.Details) {
+		return nil, false
+	}
+	return o.Details, true
+}
+
+// HasDetails returns a boolean if a field has been set.
+func (o *Error) HasDetails() bool {
+	if o != nil && !IsNil(o.Details) {
+		return true
+	}
+
+	return false
+}
+
+// SetDetails gets a reference to the given []FieldViolation and assigns it to the Details field.
+func (o *Error) SetDetails(v []FieldViolation) {
+	o.Details = v
+}
+
 func (o Error) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -337,6 +371,9 @@ func (o Error) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.OperationId) {
 		toSerialize["operation_id"] = o.OperationId
 	}
+	if !IsNil(o.Details) {
+		toSerialize["details"] = o.Details
+	}
 	return toSerialize, nil
 }
 
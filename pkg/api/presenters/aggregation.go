@@ -0,0 +1,28 @@
+package presenters
+
+import (
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+)
+
+func PresentAggregationList(aggregations []api.Aggregation) openapi.AggregationList {
+	list := openapi.AggregationList{
+		Kind:  api.AggregationListKind,
+		Items: []openapi.Aggregation{},
+	}
+	for _, aggregation := range aggregations {
+		item := openapi.Aggregation{
+			Group: aggregation.Group,
+			Count: aggregation.Count,
+		}
+		// min and max are only reported when requested
+		if len(aggregation.Min) > 0 {
+			item.Min = aggregation.Min
+		}
+		if len(aggregation.Max) > 0 {
+			item.Max = aggregation.Max
+		}
+		list.Items = append(list.Items, item)
+	}
+	return list
+}
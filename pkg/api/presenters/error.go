@@ -5,6 +5,9 @@ import (
 	"github.com/openshift-online/rh-trex-ai/pkg/errors"
 )
 
+// PresentError presents an error of the catalog, which isn't about any operation
 func PresentError(err *errors.ServiceError) openapi.Error {
-	return err.AsOpenapiError("")
+	presented := err.AsOpenapiError("")
+	presented.OperationId = nil
+	return presented
 }
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
 	"github.com/openshift-online/rh-trex-ai/pkg/errors"
 )
 
@@ -52,7 +53,8 @@ func SliceFilter(fields2Store []string, model interface{}) (*ProjectionList, *er
 		Page:  int32(reflectValue.FieldByName("Page").Int()),
 		Size:  int32(reflectValue.FieldByName("Size").Int()),
 		Total: int32(reflectValue.FieldByName("Total").Int()),
-		Items: nil,
+		// the list schemas require an array, even an empty one
+		Items: []map[string]interface{}{},
 	}
 
 	field := reflectValue.FieldByName("Items").Interface()
@@ -72,11 +74,130 @@ func SliceFilter(fields2Store []string, model interface{}) (*ProjectionList, *er
 
 	// Convert items
 	for i := 0; i < items.Len(); i++ {
-		result.Items = append(result.Items, structToMap(items.Index(i).Interface(), in, ""))
+		item, err := filterItem(items.Index(i).Interface(), in)
+		if err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, item)
 	}
 	return result, nil
 }
 
+/*
+	ObjectFilter
+
+Convert a single structure to a map holding only the requested fields.
+Fields are validated and projected exactly as SliceFilter does for the items of a list.
+
+@param fields2Store []string - list of fields to export (from `json` tag)
+
+@param item interface{} - structure to export, e.g. openapi.Dinosaur
+*/
+func ObjectFilter(fields2Store []string, item interface{}) (map[string]interface{}, *errors.ServiceError) {
+	if item == nil {
+		return nil, errors.Validation("Empty model")
+	}
+
+	var in = map[string]bool{}
+	for i := 0; i < len(fields2Store); i++ {
+		in[fields2Store[i]] = true
+	}
+
+	validateIn := make(map[string]bool)
+	for key, value := range in {
+		validateIn[key] = value
+	}
+	if err := validate(item, validateIn, ""); err != nil {
+		return nil, err
+	}
+
+	return filterItem(item, in)
+}
+
+// filterItem converts one item. Generated openapi models know how to map themselves,
+// everything else falls back to walking the struct by reflection.
+func filterItem(item interface{}, in map[string]bool) (map[string]interface{}, *errors.ServiceError) {
+	if mapped, ok := item.(openapi.MappedNullable); ok {
+		projected, err := projectMapped(mapped, in, "")
+		if err != nil {
+			return nil, errors.GeneralError("Unable to project %T: %s", item, err)
+		}
+		return projected, nil
+	}
+	return structToMap(item, in, ""), nil
+}
+
+// projectMapped keeps the requested fields of an openapi model, using the model's
+// own ToMap() rather than inspecting its struct fields one by one.
+func projectMapped(item openapi.MappedNullable, in map[string]bool, prefix string) (map[string]interface{}, error) {
+	values, err := item.ToMap()
+	if err != nil {
+		return nil, err
+	}
+	return projectMap(values, in, prefix)
+}
+
+func projectMap(values map[string]interface{}, in map[string]bool, prefix string) (map[string]interface{}, error) {
+	res := map[string]interface{}{}
+	allFields := in[prefix+".*"]
+
+	for name, value := range values {
+		prefixedName := name
+		if prefix != "" {
+			prefixedName = prefix + "." + name
+		}
+
+		switch v := value.(type) {
+		case *time.Time:
+			if in[prefixedName] || allFields {
+				res[name] = v.Format(time.RFC3339)
+			}
+		case time.Time:
+			if in[prefixedName] || allFields {
+				res[name] = v.Format(time.RFC3339)
+			}
+		case openapi.MappedNullable:
+			sub, err := projectMapped(v, in, prefixedName)
+			if err != nil {
+				return nil, err
+			}
+			if len(sub) > 0 {
+				res[name] = sub
+			}
+		default:
+			if in[prefixedName] || allFields {
+				res[name] = value
+				continue
+			}
+			// slices of sub-structures, e.g. items of a list
+			slice := reflect.ValueOf(value)
+			if slice.Kind() != reflect.Slice {
+				continue
+			}
+			var projected []interface{}
+			for i := 0; i < slice.Len(); i++ {
+				mapped, ok := slice.Index(i).Interface().(openapi.MappedNullable)
+				if !ok {
+					break
+				}
+				sub, err := projectMapped(mapped, in, prefixedName)
+				if err != nil {
+					return nil, err
+				}
+				if len(sub) == 0 {
+					break
+				}
+				projected = append(projected, sub)
+			}
+			if len(projected) > 0 {
+				res[name] = projected
+			}
+		}
+	}
+
+	return res, nil
+}
+
 func validate(model interface{}, in map[string]bool, prefix string) *errors.ServiceError {
 	if model == nil {
 		return errors.Validation("Empty model")
@@ -0,0 +1,112 @@
+package presenters
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+)
+
+func newDinosaurList(count int) openapi.DinosaurList {
+	now := time.Now()
+	list := openapi.DinosaurList{
+		Kind:  "DinosaurList",
+		Page:  1,
+		Size:  int32(count),
+		Total: int32(count),
+	}
+	for i := 0; i < count; i++ {
+		id := fmt.Sprintf("%d", i)
+		list.Items = append(list.Items, openapi.Dinosaur{
+			Id:        openapi.PtrString(id),
+			Kind:      openapi.PtrString("Dinosaur"),
+			Href:      openapi.PtrString("/api/rh-trex/v1/dinosaurs/" + id),
+			Species:   fmt.Sprintf("species-%d", i),
+			CreatedAt: openapi.PtrTime(now),
+			UpdatedAt: openapi.PtrTime(now),
+		})
+	}
+	return list
+}
+
+func TestSliceFilter(t *testing.T) {
+	RegisterTestingT(t)
+
+	list := newDinosaurList(3)
+	fields := []string{"id", "species", "created_at"}
+
+	projection, err := SliceFilter(fields, list)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(projection.Kind).To(Equal("DinosaurList"))
+	Expect(projection.Total).To(Equal(int32(3)))
+	Expect(projection.Items).To(HaveLen(3))
+	Expect(projection.Items[0]).To(HaveLen(3))
+	Expect(projection.Items[0]).To(HaveKey("species"))
+	Expect(projection.Items[0]).ToNot(HaveKey("href"))
+
+	// the openapi projection must render exactly like the reflective one
+	in := map[string]bool{}
+	for _, f := range fields {
+		in[f] = true
+	}
+	for i, item := range list.Items {
+		mapped, mapErr := projectMapped(item, in, "")
+		Expect(mapErr).ToNot(HaveOccurred())
+		expected, _ := json.Marshal(structToMap(item, in, ""))
+		actual, _ := json.Marshal(mapped)
+		Expect(actual).To(MatchJSON(expected))
+		actual, _ = json.Marshal(projection.Items[i])
+		Expect(actual).To(MatchJSON(expected))
+	}
+
+	_, err = SliceFilter([]string{"id", "weight"}, list)
+	Expect(err).To(HaveOccurred())
+	Expect(err.Reason).To(ContainSubstring("weight"))
+
+	projection, err = SliceFilter(fields, newDinosaurList(0))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(json.Marshal(projection)).To(MatchJSON(`{"kind":"DinosaurList","page":1,"size":0,"total":0,"items":[]}`))
+}
+
+func benchmarkFields() (openapi.DinosaurList, map[string]bool) {
+	return newDinosaurList(1000), map[string]bool{"id": true, "species": true}
+}
+
+func BenchmarkProjectionReflect(b *testing.B) {
+	list, in := benchmarkFields()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, item := range list.Items {
+			_ = structToMap(item, in, "")
+		}
+	}
+}
+
+func BenchmarkProjectionMapped(b *testing.B) {
+	list, in := benchmarkFields()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, item := range list.Items {
+			_, _ = projectMapped(item, in, "")
+		}
+	}
+}
+
+func TestObjectFilter(t *testing.T) {
+	RegisterTestingT(t)
+
+	dino := newDinosaurList(1).Items[0]
+
+	projection, err := ObjectFilter([]string{"id", "species"}, dino)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(projection).To(HaveLen(2))
+	Expect(projection["species"]).To(Equal("species-0"))
+
+	_, err = ObjectFilter([]string{"id", "weight"}, dino)
+	Expect(err).To(HaveOccurred())
+	Expect(err.Reason).To(ContainSubstring("weight"))
+}
@@ -1,7 +1,26 @@
 package api
 
-import "github.com/segmentio/ksuid"
+import (
+	"regexp"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+// idMaxLength is the length of the ids given by the clients at most
+const idMaxLength = 64
+
+var idRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9_.-]*[a-zA-Z0-9])?$`)
 
 func NewID() string {
 	return ksuid.New().String()
 }
+
+// ValidateID checks an id given by a client, e.g. to create a resource with a PUT request
+func ValidateID(id string) *errors.ServiceError {
+	if len(id) > idMaxLength || !idRegexp.MatchString(id) {
+		return errors.Validation("id '%s' must be at most %d letters, digits, '-', '_' or '.', starting and ending with a letter or digit", id, idMaxLength)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"context"
+	"sync"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+// admins are the usernames allowed to see and restore deleted records, see the --admin-users flag
+var (
+	adminsMu sync.RWMutex
+	admins   = map[string]bool{}
+)
+
+// SetAdmins replaces the usernames of the admins
+func SetAdmins(usernames []string) {
+	adminsMu.Lock()
+	defer adminsMu.Unlock()
+	admins = make(map[string]bool, len(usernames))
+	for _, username := range usernames {
+		admins[username] = true
+	}
+}
+
+// IsAdmin tells whether the authenticated user of the request is an admin
+func IsAdmin(ctx context.Context) bool {
+	username := GetUsernameFromContext(ctx)
+	if username == "" {
+		return false
+	}
+	adminsMu.RLock()
+	defer adminsMu.RUnlock()
+	return admins[username]
+}
+
+// CheckAdmin fails with a forbidden error when the authenticated user of the request is not an admin
+func CheckAdmin(ctx context.Context, action string) *errors.ServiceError {
+	if !IsAdmin(ctx) {
+		return errors.Forbidden("Only admins can %s", action)
+	}
+	return nil
+}
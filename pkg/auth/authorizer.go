@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang/glog"
+	"github.com/openshift-online/ocm-sdk-go/authentication"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/client/ocm"
+)
+
+// Authorizer decides whether the account of a request may do an action on a resource type, it is the
+// backend of the authorization middleware, selected with --authz-backend
+type Authorizer interface {
+	Authorize(ctx context.Context, subject Subject, action, resourceType string) (allowed bool, err error)
+}
+
+// Subject is the authenticated account of a request
+type Subject struct {
+	Username string
+	// Groups are the groups claim of the JWT token
+	Groups []string
+	// Claims are the claims of the JWT token, empty when the request has none
+	Claims jwt.MapClaims
+}
+
+// GetSubjectFromContext returns the authenticated account of the request context
+func GetSubjectFromContext(ctx context.Context) Subject {
+	subject := Subject{Username: GetUsernameFromContext(ctx)}
+	token, err := authentication.TokenFromContext(ctx)
+	if err != nil || token == nil {
+		return subject
+	}
+	if claims, ok := token.Claims.(jwt.MapClaims); ok {
+		subject.Claims = claims
+		groups, _ := claims["groups"].([]interface{})
+		for _, group := range groups {
+			if name, ok := group.(string); ok {
+				subject.Groups = append(subject.Groups, name)
+			}
+		}
+	}
+	return subject
+}
+
+// ocmAuthorizer asks the OCM access reviews
+type ocmAuthorizer struct {
+	ocmClient *ocm.Client
+}
+
+var _ Authorizer = &ocmAuthorizer{}
+
+func NewOCMAuthorizer(ocmClient *ocm.Client) Authorizer {
+	return &ocmAuthorizer{ocmClient: ocmClient}
+}
+
+func (a ocmAuthorizer) Authorize(ctx context.Context, subject Subject, action, resourceType string) (bool, error) {
+	return a.ocmClient.Authorization.AccessReview(ctx, subject.Username, action, resourceType, "", "", "")
+}
+
+// allowAllAuthorizer allows every authenticated account, for the development environments
+type allowAllAuthorizer struct{}
+
+var _ Authorizer = &allowAllAuthorizer{}
+
+func NewAllowAllAuthorizer() Authorizer {
+	glog.Warning("The allow-all authorization backend allows every authenticated account, it's meant for development only")
+	return &allowAllAuthorizer{}
+}
+
+func (a allowAllAuthorizer) Authorize(ctx context.Context, subject Subject, action, resourceType string) (bool, error) {
+	return true, nil
+}
@@ -5,68 +5,76 @@ package auth
    parameters to be declared for each route in a microservice. This is not meant
    to handle more complex access review calls in particular scopes, but rather
    just authz calls at the application scope
-
-  This is a big TODO, not ready for consumption
 */
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
-	"github.com/openshift-online/rh-trex-ai/pkg/client/ocm"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+)
+
+// The actions declared by the routes, as the OCM access reviews and the RBAC policies know them
+const (
+	ActionGet    = "get"
+	ActionList   = "list"
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
 )
 
 type AuthorizationMiddleware interface {
-	AuthorizeApi(next http.Handler) http.Handler
+	// AuthorizeApi returns the handler of a route, calling next when the account of the request is allowed
+	// to do the action on the resource type, e.g.
+	//   router.HandleFunc("/{id}", authzMiddleware.AuthorizeApi(auth.ActionGet, "Dinosaur", handler.Get))
+	// The route must be authenticated first, the account being the subject of the request context.
+	AuthorizeApi(action, resourceType string, next http.HandlerFunc) http.HandlerFunc
+	// Authorize checks the action of the account of the request context, for the handlers running several
+	// actions in one request, e.g. the bulk operations
+	Authorize(ctx context.Context, action, resourceType string) *errors.ServiceError
 }
 
 type authzMiddleware struct {
-	action       string
-	resourceType string
-
-	ocmClient *ocm.Client
+	authorizer Authorizer
 }
 
 var _ AuthorizationMiddleware = &authzMiddleware{}
 
-func NewAuthzMiddleware(ocmClient *ocm.Client, action, resourceType string) AuthorizationMiddleware {
+func NewAuthzMiddleware(authorizer Authorizer) AuthorizationMiddleware {
 	return &authzMiddleware{
-		ocmClient:    ocmClient,
-		action:       action,
-		resourceType: resourceType,
+		authorizer: authorizer,
 	}
 }
 
-func (a authzMiddleware) AuthorizeApi(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+func (a authzMiddleware) AuthorizeApi(action, resourceType string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
-
-		// Get username from context
-		username := GetUsernameFromContext(ctx)
-		if username == "" {
-			_ = fmt.Errorf("authenticated username not present in request context")
-			// TODO
-			//body := api.E500.Format(r, "Authentication details not present in context")
-			//api.SendError(w, r, &body)
+		if err := a.Authorize(ctx, action, resourceType); err != nil {
+			handleError(ctx, w, err.Code, err.Reason)
 			return
 		}
+		next(w, r)
+	}
+}
 
-		allowed, err := a.ocmClient.Authorization.AccessReview(
-			ctx, username, a.action, a.resourceType, "", "", "")
-		if err != nil {
-			_ = fmt.Errorf("unable to make authorization request: %s", err)
-			// TODO
-			//body := api.E500.Format(r, "Unable to make authorization request")
-			//api.SendError(w, r, &body)
-			return
-		}
+func (a authzMiddleware) Authorize(ctx context.Context, action, resourceType string) *errors.ServiceError {
+	// Get the account from context
+	subject := GetSubjectFromContext(ctx)
+	username := subject.Username
+	if username == "" {
+		return errors.Unauthenticated("Authenticated username not present in request context")
+	}
 
-		if allowed {
-			next.ServeHTTP(w, r)
-		}
+	allowed, err := a.authorizer.Authorize(ctx, subject, action, resourceType)
+	if err != nil {
+		logger.NewOCMLogger(ctx).WithError(err).Error(fmt.Sprintf("Unable to review the access of '%s' to %s %s: %s", username, action, resourceType, err))
+		return errors.GeneralError("Unable to make authorization request")
+	}
 
-		// TODO
-		//body := api.E403.Format(r, "")
-		//api.SendError(w, r, &body)
-	})
+	if !allowed {
+		return errors.Unauthorized("Account '%s' is not allowed to %s %s", username, action, resourceType)
+	}
+	return nil
 }
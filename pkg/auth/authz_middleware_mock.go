@@ -1,9 +1,12 @@
 package auth
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/golang/glog"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
 )
 
 type authzMiddlewareMock struct{}
@@ -14,9 +17,14 @@ func NewAuthzMiddlewareMock() AuthorizationMiddleware {
 	return &authzMiddlewareMock{}
 }
 
-func (a authzMiddlewareMock) AuthorizeApi(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		glog.Infof("Mock authz allows <any>/<any> for %q/%q", r.Method, r.URL)
-		next.ServeHTTP(w, r)
-	})
+func (a authzMiddlewareMock) AuthorizeApi(action, resourceType string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		glog.Infof("Mock authz allows %s/%s for %q/%q", action, resourceType, r.Method, r.URL)
+		next(w, r)
+	}
+}
+
+func (a authzMiddlewareMock) Authorize(ctx context.Context, action, resourceType string) *errors.ServiceError {
+	glog.Infof("Mock authz allows %s/%s", action, resourceType)
+	return nil
 }
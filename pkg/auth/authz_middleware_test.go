@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex-ai/pkg/client/ocm"
+)
+
+// accessReviews allows the actions of its map, by account, and records the reviews
+type accessReviews struct {
+	allowed map[string]string
+	err     error
+	reviews []string
+}
+
+var _ ocm.Authorization = &accessReviews{}
+
+func (a *accessReviews) SelfAccessReview(ctx context.Context, action, resourceType, organizationID, subscriptionID, clusterID string) (bool, error) {
+	return false, fmt.Errorf("unexpected self access review")
+}
+
+func (a *accessReviews) AccessReview(ctx context.Context, username, action, resourceType, organizationID, subscriptionID, clusterID string) (bool, error) {
+	a.reviews = append(a.reviews, fmt.Sprintf("%s %s %s", username, action, resourceType))
+	return a.allowed[username] == action, a.err
+}
+
+func TestAuthorizeApi(t *testing.T) {
+	RegisterTestingT(t)
+
+	reviews := &accessReviews{allowed: map[string]string{"alice": ActionGet}}
+	middleware := NewAuthzMiddleware(NewOCMAuthorizer(&ocm.Client{Authorization: reviews}))
+	handler := middleware.AuthorizeApi(ActionGet, "Dinosaur", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	serve := func(username string) (*httptest.ResponseRecorder, openapi.Error) {
+		r := httptest.NewRequest(http.MethodGet, "/api/rh-trex/v1/dinosaurs/1", nil)
+		if username != "" {
+			r = r.WithContext(SetUsernameContext(r.Context(), username))
+		}
+		w := httptest.NewRecorder()
+		handler(w, r)
+		var body openapi.Error
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w, body
+	}
+
+	w, _ := serve("alice")
+	Expect(w.Code).To(Equal(http.StatusNoContent))
+	Expect(reviews.reviews).To(Equal([]string{"alice get Dinosaur"}))
+
+	w, body := serve("bob")
+	Expect(w.Code).To(Equal(http.StatusForbidden))
+	Expect(body.GetReason()).To(Equal("Account 'bob' is not allowed to get Dinosaur"))
+
+	w, _ = serve("")
+	Expect(w.Code).To(Equal(http.StatusUnauthorized))
+	Expect(reviews.reviews).To(HaveLen(2))
+
+	reviews.err = fmt.Errorf("connection refused")
+	w, body = serve("alice")
+	Expect(w.Code).To(Equal(http.StatusInternalServerError))
+	Expect(body.GetReason()).To(Equal("Unable to make authorization request"))
+}
@@ -2,7 +2,6 @@ package auth
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/errors"
@@ -19,15 +18,5 @@ func handleError(ctx context.Context, w http.ResponseWriter, code errors.Service
 		log.Error(err.Error())
 	}
 
-	writeJSONResponse(w, err.HttpCode, err.AsOpenapiError(operationID))
-}
-
-func writeJSONResponse(w http.ResponseWriter, code int, payload interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
-
-	if payload != nil {
-		response, _ := json.Marshal(payload)
-		_, _ = w.Write(response)
-	}
+	err.WriteResponse(ctx, w, operationID)
 }
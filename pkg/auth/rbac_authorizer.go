@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/glog"
+)
+
+// wildcard matches every kind or verb of a role rule
+const wildcard = "*"
+
+// Policy is the local RBAC policy of the rbac authorization backend, e.g.
+//
+//	roles:
+//	- name: dinosaur-viewer
+//	  rules:
+//	  - kinds: [Dinosaur]
+//	    verbs: [get, list]
+//	bindings:
+//	- role: dinosaur-viewer
+//	  subjects:
+//	  - username: alice
+//	  - group: paleontologists
+//	  - claim: org_id
+//	    value: "12345"
+type Policy struct {
+	Roles    []Role    `json:"roles"`
+	Bindings []Binding `json:"bindings"`
+}
+
+// Role allows the verbs of its rules, the actions declared by the routes, on their kinds
+type Role struct {
+	Name  string     `json:"name"`
+	Rules []RoleRule `json:"rules"`
+}
+
+type RoleRule struct {
+	Kinds []string `json:"kinds"`
+	Verbs []string `json:"verbs"`
+}
+
+// Binding grants a role to subjects
+type Binding struct {
+	Role     string           `json:"role"`
+	Subjects []BindingSubject `json:"subjects"`
+}
+
+// BindingSubject matches the accounts by username, by group or by the value of a JWT token claim
+type BindingSubject struct {
+	Username string `json:"username,omitempty"`
+	Group    string `json:"group,omitempty"`
+	Claim    string `json:"claim,omitempty"`
+	Value    string `json:"value,omitempty"`
+}
+
+// ParsePolicy reads and checks a YAML RBAC policy
+func ParsePolicy(data []byte) (*Policy, error) {
+	policy := &Policy{}
+	if err := yaml.Unmarshal(data, policy); err != nil {
+		return nil, err
+	}
+	roles := map[string]bool{}
+	for _, role := range policy.Roles {
+		if role.Name == "" {
+			return nil, fmt.Errorf("a role has no name")
+		}
+		if roles[role.Name] {
+			return nil, fmt.Errorf("role '%s' is defined twice", role.Name)
+		}
+		roles[role.Name] = true
+		for _, rule := range role.Rules {
+			for _, verb := range rule.Verbs {
+				switch verb {
+				case wildcard, ActionGet, ActionList, ActionCreate, ActionUpdate, ActionDelete:
+				default:
+					return nil, fmt.Errorf("role '%s' has the unknown verb '%s'", role.Name, verb)
+				}
+			}
+		}
+	}
+	for _, binding := range policy.Bindings {
+		if !roles[binding.Role] {
+			return nil, fmt.Errorf("a binding grants the undefined role '%s'", binding.Role)
+		}
+		for _, subject := range binding.Subjects {
+			set := 0
+			for _, field := range []string{subject.Username, subject.Group, subject.Claim} {
+				if field != "" {
+					set++
+				}
+			}
+			if set != 1 {
+				return nil, fmt.Errorf("a subject of the binding of role '%s' must have one of username, group or claim", binding.Role)
+			}
+			if subject.Claim != "" && subject.Value == "" {
+				return nil, fmt.Errorf("the claim '%s' of the binding of role '%s' has no value", subject.Claim, binding.Role)
+			}
+		}
+	}
+	return policy, nil
+}
+
+// Allows tells whether a role bound to the subject allows the action on the resource type
+func (p *Policy) Allows(subject Subject, action, resourceType string) bool {
+	for _, binding := range p.Bindings {
+		if !binding.matches(subject) {
+			continue
+		}
+		for _, role := range p.Roles {
+			if role.Name == binding.Role && role.allows(action, resourceType) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (b Binding) matches(subject Subject) bool {
+	for _, s := range b.Subjects {
+		switch {
+		case s.Username != "":
+			if s.Username == subject.Username {
+				return true
+			}
+		case s.Group != "":
+			if contains(subject.Groups, s.Group) {
+				return true
+			}
+		case s.Claim != "":
+			if claimMatches(subject.Claims[s.Claim], s.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// claimMatches compares the claim, or each value of a list claim, to the value of a binding
+func claimMatches(claim interface{}, value string) bool {
+	switch claim := claim.(type) {
+	case nil:
+		return false
+	case []interface{}:
+		for _, item := range claim {
+			if claimMatches(item, value) {
+				return true
+			}
+		}
+		return false
+	default:
+		return fmt.Sprint(claim) == value
+	}
+}
+
+func (r Role) allows(action, resourceType string) bool {
+	for _, rule := range r.Rules {
+		if (contains(rule.Kinds, wildcard) || contains(rule.Kinds, resourceType)) &&
+			(contains(rule.Verbs, wildcard) || contains(rule.Verbs, action)) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// rbacAuthorizer follows a policy file, reloaded when it changes
+type rbacAuthorizer struct {
+	file           string
+	reloadInterval time.Duration
+
+	mu        sync.RWMutex
+	policy    *Policy
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+var _ Authorizer = &rbacAuthorizer{}
+
+// NewRBACAuthorizer loads the policy file, which is checked for changes every reloadInterval; a changed
+// file which can't be loaded is logged and the previous policy is kept
+func NewRBACAuthorizer(file string, reloadInterval time.Duration) (Authorizer, error) {
+	a := &rbacAuthorizer{file: file, reloadInterval: reloadInterval}
+	if err := a.load(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *rbacAuthorizer) Authorize(ctx context.Context, subject Subject, action, resourceType string) (bool, error) {
+	a.reload()
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.policy.Allows(subject, action, resourceType), nil
+}
+
+// reload loads the policy file again when it changed since it was loaded, at most every reloadInterval
+func (a *rbacAuthorizer) reload() {
+	a.mu.RLock()
+	due := time.Since(a.checkedAt) >= a.reloadInterval
+	a.mu.RUnlock()
+	if !due {
+		return
+	}
+	if err := a.load(); err != nil {
+		glog.Errorf("Unable to reload the RBAC policy, keeping the previous one: %s", err)
+	}
+}
+
+func (a *rbacAuthorizer) load() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.checkedAt = time.Now()
+	info, err := os.Stat(a.file)
+	if err != nil {
+		return fmt.Errorf("unable to read RBAC policy file %s: %s", a.file, err)
+	}
+	if a.policy != nil && info.ModTime().Equal(a.modTime) {
+		return nil
+	}
+	data, err := os.ReadFile(a.file)
+	if err != nil {
+		return fmt.Errorf("unable to read RBAC policy file %s: %s", a.file, err)
+	}
+	policy, err := ParsePolicy(data)
+	if err != nil {
+		return fmt.Errorf("invalid RBAC policy file %s: %s", a.file, err)
+	}
+	if a.policy != nil {
+		glog.Infof("Reloaded the RBAC policy file %s", a.file)
+	}
+	a.policy = policy
+	a.modTime = info.ModTime()
+	return nil
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	. "github.com/onsi/gomega"
+)
+
+const testPolicy = `
+roles:
+- name: dinosaur-viewer
+  rules:
+  - kinds: [Dinosaur]
+    verbs: [get, list]
+- name: admin
+  rules:
+  - kinds: ["*"]
+    verbs: ["*"]
+bindings:
+- role: dinosaur-viewer
+  subjects:
+  - group: paleontologists
+  - claim: org_id
+    value: "12345"
+- role: admin
+  subjects:
+  - username: alice
+`
+
+func TestPolicyAllows(t *testing.T) {
+	RegisterTestingT(t)
+
+	policy, err := ParsePolicy([]byte(testPolicy))
+	Expect(err).NotTo(HaveOccurred())
+
+	tests := []struct {
+		name     string
+		subject  Subject
+		action   string
+		kind     string
+		expected bool
+	}{
+		{"wildcard role", Subject{Username: "alice"}, ActionDelete, "Habitat", true},
+		{"group binding", Subject{Username: "bob", Groups: []string{"paleontologists"}}, ActionList, "Dinosaur", true},
+		{"verb out of the role", Subject{Username: "bob", Groups: []string{"paleontologists"}}, ActionCreate, "Dinosaur", false},
+		{"kind out of the role", Subject{Username: "bob", Groups: []string{"paleontologists"}}, ActionGet, "Habitat", false},
+		{"claim binding", Subject{Username: "carol", Claims: jwt.MapClaims{"org_id": "12345"}}, ActionGet, "Dinosaur", true},
+		{"list claim binding", Subject{Username: "carol", Claims: jwt.MapClaims{"org_id": []interface{}{"1", "12345"}}}, ActionGet, "Dinosaur", true},
+		{"other claim value", Subject{Username: "carol", Claims: jwt.MapClaims{"org_id": "1"}}, ActionGet, "Dinosaur", false},
+		{"unbound account", Subject{Username: "dave"}, ActionGet, "Dinosaur", false},
+	}
+	for _, test := range tests {
+		Expect(policy.Allows(test.subject, test.action, test.kind)).To(Equal(test.expected), test.name)
+	}
+}
+
+func TestParsePolicyErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	for policy, message := range map[string]string{
+		"roles: [{name: viewer, rules: [{kinds: [Dinosaur], verbs: [read]}]}]":        "role 'viewer' has the unknown verb 'read'",
+		"bindings: [{role: viewer, subjects: [{username: alice}]}]":                   "a binding grants the undefined role 'viewer'",
+		"roles: [{name: viewer}]\nbindings: [{role: viewer, subjects: [{}]}]":         "a subject of the binding of role 'viewer' must have one of username, group or claim",
+		"roles: [{name: viewer}]\nbindings: [{role: viewer, subjects: [{claim: a}]}]": "the claim 'a' of the binding of role 'viewer' has no value",
+		"roles: [{name: viewer}, {name: viewer}]":                                     "role 'viewer' is defined twice",
+	} {
+		_, err := ParsePolicy([]byte(policy))
+		Expect(err).To(MatchError(message))
+	}
+}
+
+func TestRBACAuthorizerReload(t *testing.T) {
+	RegisterTestingT(t)
+
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	Expect(os.WriteFile(file, []byte(testPolicy), 0600)).To(Succeed())
+	authorizer, err := NewRBACAuthorizer(file, 0)
+	Expect(err).NotTo(HaveOccurred())
+
+	bob := Subject{Username: "bob"}
+	allowed, err := authorizer.Authorize(context.Background(), bob, ActionGet, "Dinosaur")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(allowed).To(BeFalse())
+
+	// the modification time tells the changes apart
+	modified := time.Now().Add(time.Minute)
+	Expect(os.WriteFile(file, []byte(testPolicy+"  - username: bob\n"), 0600)).To(Succeed())
+	Expect(os.Chtimes(file, modified, modified)).To(Succeed())
+	allowed, _ = authorizer.Authorize(context.Background(), bob, ActionGet, "Dinosaur")
+	Expect(allowed).To(BeTrue())
+
+	// an invalid policy keeps the previous one
+	modified = modified.Add(time.Minute)
+	Expect(os.WriteFile(file, []byte("roles: ["), 0600)).To(Succeed())
+	Expect(os.Chtimes(file, modified, modified)).To(Succeed())
+	allowed, _ = authorizer.Authorize(context.Background(), bob, ActionGet, "Dinosaur")
+	Expect(allowed).To(BeTrue())
+
+	_, err = NewRBACAuthorizer(filepath.Join(t.TempDir(), "missing.yaml"), 0)
+	Expect(err).To(HaveOccurred())
+}
@@ -57,5 +57,15 @@ func runServe(getSpecData func() ([]byte, error)) {
 		controllersServer.Start()
 	}()
 
+	go func() {
+		purgeServer := pkgserver.NewDefaultPurgeServer(env)
+		purgeServer.Start()
+	}()
+
+	go func() {
+		idempotencyKeyExpiryServer := pkgserver.NewDefaultIdempotencyKeyExpiryServer(env)
+		idempotencyKeyExpiryServer.Start()
+	}()
+
 	select {}
 }
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// The authorization backends, deciding whether an account may do the action declared by a route
+const (
+	// AuthzBackendOCM asks the OCM access reviews
+	AuthzBackendOCM = "ocm"
+	// AuthzBackendRBAC follows the roles and bindings of a local policy file
+	AuthzBackendRBAC = "rbac"
+	// AuthzBackendAllowAll allows every authenticated account, for development only
+	AuthzBackendAllowAll = "allow-all"
+)
+
+// AuthzConfig selects the authorization backend used when --enable-authz is set.
+type AuthzConfig struct {
+	Backend string `json:"backend"`
+	// PolicyFile is the YAML RBAC policy of the rbac backend
+	PolicyFile string `json:"policy_file"`
+	// PolicyReloadInterval is how often the policy file is checked for changes
+	PolicyReloadInterval time.Duration `json:"policy_reload_interval"`
+}
+
+func NewAuthzConfig() *AuthzConfig {
+	return &AuthzConfig{
+		Backend:              AuthzBackendOCM,
+		PolicyFile:           "",
+		PolicyReloadInterval: 30 * time.Second,
+	}
+}
+
+func (c *AuthzConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.Backend, "authz-backend", c.Backend, "Authorization backend: ocm, rbac or allow-all")
+	fs.StringVar(&c.PolicyFile, "authz-policy-file", c.PolicyFile, "YAML RBAC policy file of the rbac authorization backend")
+	fs.DurationVar(&c.PolicyReloadInterval, "authz-policy-reload-interval", c.PolicyReloadInterval, "How often the RBAC policy file is reloaded when it changes")
+}
+
+func (c *AuthzConfig) ReadFiles() error {
+	switch c.Backend {
+	case AuthzBackendOCM, AuthzBackendAllowAll:
+	case AuthzBackendRBAC:
+		if c.PolicyFile == "" {
+			return fmt.Errorf("the %s authorization backend needs a policy file", c.Backend)
+		}
+		// the policy file is read, and reloaded, by the backend, relative to the project root like the other files
+		if !filepath.IsAbs(c.PolicyFile) {
+			c.PolicyFile = filepath.Join(GetProjectRootDir(), c.PolicyFile)
+		}
+	default:
+		return fmt.Errorf("unknown authorization backend '%s', it must be %s, %s or %s",
+			c.Backend, AuthzBackendOCM, AuthzBackendRBAC, AuthzBackendAllowAll)
+	}
+	return nil
+}
@@ -23,6 +23,8 @@ type ApplicationConfig struct {
 	Database    *DatabaseConfig    `json:"database"`
 	OCM         *OCMConfig         `json:"ocm"`
 	Sentry      *SentryConfig      `json:"sentry"`
+	Purge       *PurgeConfig       `json:"purge"`
+	Authz       *AuthzConfig       `json:"authz"`
 }
 
 func NewApplicationConfig() *ApplicationConfig {
@@ -33,6 +35,8 @@ func NewApplicationConfig() *ApplicationConfig {
 		Database:    NewDatabaseConfig(),
 		OCM:         NewOCMConfig(),
 		Sentry:      NewSentryConfig(),
+		Purge:       NewPurgeConfig(),
+		Authz:       NewAuthzConfig(),
 	}
 }
 
@@ -44,6 +48,8 @@ func (c *ApplicationConfig) AddFlags(flagset *pflag.FlagSet) {
 	c.Database.AddFlags(flagset)
 	c.OCM.AddFlags(flagset)
 	c.Sentry.AddFlags(flagset)
+	c.Purge.AddFlags(flagset)
+	c.Authz.AddFlags(flagset)
 }
 
 func (c *ApplicationConfig) ReadFiles() []string {
@@ -57,6 +63,8 @@ func (c *ApplicationConfig) ReadFiles() []string {
 		{c.Metrics.ReadFiles, "Metrics"},
 		{c.HealthCheck.ReadFiles, "HealthCheck"},
 		{c.Sentry.ReadFiles, "Sentry"},
+		{c.Purge.ReadFiles, "Purge"},
+		{c.Authz.ReadFiles, "Authz"},
 	}
 	var messages []string
 	for _, rf := range readFiles {
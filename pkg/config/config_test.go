@@ -3,7 +3,9 @@ package config
 import (
 	"log"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 )
@@ -67,6 +69,25 @@ func TestConfigReadQuotedFile(t *testing.T) {
 	Expect(err).NotTo(HaveOccurred())
 	Expect(val).To(Equal("example"))
 }
+
+func TestPurgeRetentionFor(t *testing.T) {
+	RegisterTestingT(t)
+
+	purgeConfig := NewPurgeConfig()
+	purgeConfig.KindRetention = map[string]string{"Dinosaurs": "48h", "Rockets": "soon"}
+
+	retention, err := purgeConfig.RetentionFor("Dinosaurs")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(retention).To(Equal(48 * time.Hour))
+
+	retention, err = purgeConfig.RetentionFor("Fossils")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(retention).To(Equal(purgeConfig.Retention))
+
+	_, err = purgeConfig.RetentionFor("Rockets")
+	Expect(err).To(HaveOccurred())
+}
+
 func createConfigFile(namePrefix, contents string) (*os.File, error) {
 	configFile, err := os.CreateTemp("", namePrefix)
 	if err != nil {
@@ -78,3 +99,19 @@ func createConfigFile(namePrefix, contents string) (*os.File, error) {
 	err = configFile.Close()
 	return configFile, err
 }
+
+func TestAuthzConfigReadFiles(t *testing.T) {
+	RegisterTestingT(t)
+
+	c := NewAuthzConfig()
+	Expect(c.ReadFiles()).To(Succeed())
+
+	c.Backend = AuthzBackendRBAC
+	Expect(c.ReadFiles()).To(MatchError("the rbac authorization backend needs a policy file"))
+	c.PolicyFile = "rbac.yaml"
+	Expect(c.ReadFiles()).To(Succeed())
+	Expect(c.PolicyFile).To(Equal(filepath.Join(GetProjectRootDir(), "rbac.yaml")))
+
+	c.Backend = "ldap"
+	Expect(c.ReadFiles()).To(MatchError("unknown authorization backend 'ldap', it must be ocm, rbac or allow-all"))
+}
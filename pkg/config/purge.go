@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// PurgeConfig tells how long soft-deleted records are kept before the purge job removes them for good.
+type PurgeConfig struct {
+	EnablePurge bool          `json:"enable_purge"`
+	Interval    time.Duration `json:"interval"`
+	Retention   time.Duration `json:"retention"`
+	// KindRetention overrides Retention for some kinds, e.g. Dinosaurs=48h
+	KindRetention map[string]string `json:"kind_retention"`
+}
+
+func NewPurgeConfig() *PurgeConfig {
+	return &PurgeConfig{
+		EnablePurge:   false,
+		Interval:      time.Hour,
+		Retention:     30 * 24 * time.Hour,
+		KindRetention: map[string]string{},
+	}
+}
+
+func (c *PurgeConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&c.EnablePurge, "enable-purge", c.EnablePurge, "Enable the job permanently deleting the soft-deleted records past their retention, off by default")
+	fs.DurationVar(&c.Interval, "purge-interval", c.Interval, "How often the purge job runs")
+	fs.DurationVar(&c.Retention, "purge-retention", c.Retention, "How long soft-deleted records are kept before they are purged")
+	fs.StringToStringVar(&c.KindRetention, "purge-kind-retention", c.KindRetention, "Retention of the soft-deleted records of some kinds, e.g. Dinosaurs=48h")
+}
+
+func (c *PurgeConfig) ReadFiles() error {
+	return nil
+}
+
+// RetentionFor returns how long the soft-deleted records of a kind are kept
+func (c *PurgeConfig) RetentionFor(kind string) (time.Duration, error) {
+	value, ok := c.KindRetention[kind]
+	if !ok {
+		return c.Retention, nil
+	}
+	retention, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid purge retention of %s: %s", kind, err)
+	}
+	return retention, nil
+}
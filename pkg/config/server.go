@@ -19,22 +19,34 @@ type ServerConfig struct {
 	JwkCertFile   string        `json:"jwk_cert_file"`
 	JwkCertURL    string        `json:"jwk_cert_url"`
 	ACLFile       string        `json:"acl_file"`
+	AdminUsers    []string      `json:"admin_users"`
+	// IdempotencyKeyTTL is how long the response of a request sent with an Idempotency-Key is replayed
+	IdempotencyKeyTTL time.Duration `json:"idempotency_key_ttl"`
+	// EnableRequestValidation rejects the requests which don't match the OpenAPI specification
+	EnableRequestValidation bool `json:"enable_request_validation"`
+	// EnableResponseValidation records the responses which don't match the OpenAPI specification, for the tests
+	EnableResponseValidation bool `json:"enable_response_validation"`
+	// CreateOnPutKinds are the kinds whose PUT requests create the missing resources with the id of their path
+	CreateOnPutKinds []string `json:"create_on_put_kinds"`
 }
 
 func NewServerConfig() *ServerConfig {
 	return &ServerConfig{
-		Hostname:      "",
-		BindAddress:   "localhost:8000",
-		ReadTimeout:   5 * time.Second,
-		WriteTimeout:  30 * time.Second,
-		EnableHTTPS:   false,
-		EnableJWT:     true,
-		EnableAuthz:   true,
-		JwkCertFile:   "",
-		JwkCertURL:    "https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/certs",
-		ACLFile:       "",
-		HTTPSCertFile: "",
-		HTTPSKeyFile:  "",
+		Hostname:          "",
+		BindAddress:       "localhost:8000",
+		ReadTimeout:       5 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		EnableHTTPS:       false,
+		EnableJWT:         true,
+		EnableAuthz:       true,
+		JwkCertFile:       "",
+		JwkCertURL:        "https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/certs",
+		ACLFile:           "",
+		HTTPSCertFile:     "",
+		HTTPSKeyFile:      "",
+		AdminUsers:        []string{},
+		IdempotencyKeyTTL: 24 * time.Hour,
+		CreateOnPutKinds:  []string{},
 	}
 }
 
@@ -48,11 +60,26 @@ func (s *ServerConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&s.EnableHTTPS, "enable-https", s.EnableHTTPS, "Enable HTTPS rather than HTTP")
 	fs.BoolVar(&s.EnableJWT, "enable-jwt", s.EnableJWT, "Enable JWT authentication validation")
 	fs.BoolVar(&s.EnableAuthz, "enable-authz", s.EnableAuthz, "Enable Authorization on endpoints, should only be disabled for debug")
+	fs.BoolVar(&s.EnableRequestValidation, "enable-request-validation", s.EnableRequestValidation, "Reject the requests whose parameters or body don't match the OpenAPI specification")
+	fs.BoolVar(&s.EnableResponseValidation, "enable-response-validation", s.EnableResponseValidation, "Record the responses whose status, content type or body don't match the OpenAPI specification, for the tests to fail on them")
 	fs.StringVar(&s.JwkCertFile, "jwk-cert-file", s.JwkCertFile, "JWK Certificate file")
 	fs.StringVar(&s.JwkCertURL, "jwk-cert-url", s.JwkCertURL, "JWK Certificate URL")
 	fs.StringVar(&s.ACLFile, "acl-file", s.ACLFile, "Access control list file")
+	fs.StringSliceVar(&s.AdminUsers, "admin-users", s.AdminUsers, "Usernames allowed to list and restore deleted records")
+	fs.StringSliceVar(&s.CreateOnPutKinds, "create-on-put-kinds", s.CreateOnPutKinds, "Kinds whose PUT requests create the missing resources instead of failing with 404, e.g. Dinosaur")
+	fs.DurationVar(&s.IdempotencyKeyTTL, "idempotency-key-ttl", s.IdempotencyKeyTTL, "How long the response of a request sent with an Idempotency-Key header is replayed to its retries")
 }
 
 func (s *ServerConfig) ReadFiles() error {
 	return nil
 }
+
+// CreatesOnPut tells whether the PUT requests of the kind create the missing resources
+func (s *ServerConfig) CreatesOnPut(kind string) bool {
+	for _, k := range s.CreateOnPutKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
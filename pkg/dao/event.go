@@ -8,6 +8,7 @@ import (
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	dbContext "github.com/openshift-online/rh-trex-ai/pkg/db/db_context"
 )
 
 type EventDao interface {
@@ -45,6 +46,11 @@ func (d *sqlEventDao) Create(ctx context.Context, event *api.Event) (*api.Event,
 		return nil, err
 	}
 
+	// the event of a dry run is rolled back, its listeners are not notified
+	if dbContext.DryRun(ctx) {
+		return event, nil
+	}
+
 	notify := fmt.Sprintf("select pg_notify('%s', '%s')", "events", event.ID)
 
 	err := g2.Exec(notify).Error
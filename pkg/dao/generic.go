@@ -2,10 +2,13 @@ package dao
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jinzhu/inflection"
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
 )
@@ -24,18 +27,28 @@ func NewWhere(sql string, values []any) Where {
 
 type GenericDao interface {
 	Fetch(offset int, limit int, resourceList interface{}) error
+	Get(id string, resource interface{}) error
 
 	GetInstanceDao(ctx context.Context, model interface{}) GenericDao
 	Preload(preload string)
+	Select(columns []string)
 	OrderBy(orderBy string)
 	Joins(sql string)
 	Group(sql string)
 	Where(where Where)
+	Unscoped()
 	Count(model interface{}, total *int64)
+	Aggregate(selects []string, groupBy []string, results *[]map[string]interface{}) error
 	Validate(resourceList interface{}) error
+	Purge(deletedBefore time.Time) (int64, error)
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
 
 	GetTableName() string
+	GetColumnName(field string) (string, bool)
+	IsTimestampColumn(relationPath []string, column string) bool
 	GetTableRelation(fieldName string) (TableRelation, bool)
+	GetTableRelationPath(fieldNames []string) ([]TableRelation, bool)
+	GetPreloadRelationPath(fieldNames []string) ([]TableRelation, bool)
 }
 
 var _ GenericDao = &sqlGenericDao{}
@@ -47,7 +60,9 @@ type sqlGenericDao struct {
 
 // TableRelation represents a relationship between two tables. They can be joined,
 // ON TableName.ColumnName = ForeignTableName.ForeignColumnName
+// Name is the field of the api model holding the related resource, as used for preloading.
 type TableRelation struct {
+	Name              string
 	TableName         string
 	ColumnName        string
 	ForeignTableName  string
@@ -66,13 +81,24 @@ func (d *sqlGenericDao) GetInstanceDao(ctx context.Context, model interface{}) G
 }
 
 func (d *sqlGenericDao) Fetch(offset int, limit int, resourceList interface{}) error {
-	return d.g2.Debug().Offset(offset).Limit(limit).Find(resourceList).Error
+	g2 := d.g2.Debug().Offset(offset).Limit(limit)
+	// the cloned statement forgets whether the soft-deleted records are included
+	g2.Statement.Unscoped = d.g2.Statement.Unscoped
+	return g2.Find(resourceList).Error
+}
+
+func (d *sqlGenericDao) Get(id string, resource interface{}) error {
+	return d.g2.Where(fmt.Sprintf("%s.id = ?", d.GetTableName()), id).Take(resource).Error
 }
 
 func (d *sqlGenericDao) Preload(preload string) {
 	d.g2 = d.g2.Preload(preload)
 }
 
+func (d *sqlGenericDao) Select(columns []string) {
+	d.g2 = d.g2.Select(columns)
+}
+
 func (d *sqlGenericDao) OrderBy(orderBy string) {
 	d.g2 = d.g2.Order(orderBy)
 }
@@ -89,6 +115,11 @@ func (d *sqlGenericDao) Where(where Where) {
 	d.g2 = d.g2.Where(where.sql, where.values...)
 }
 
+// Unscoped includes the soft-deleted records
+func (d *sqlGenericDao) Unscoped() {
+	d.g2 = d.g2.Unscoped()
+}
+
 func (d *sqlGenericDao) Count(model interface{}, total *int64) {
 	// Creates new session which already clears all statement clauses
 	g2 := d.g2.Session(&gorm.Session{DryRun: false}).Model(model)
@@ -99,9 +130,20 @@ func (d *sqlGenericDao) Count(model interface{}, total *int64) {
 	if where, ok := d.g2.Statement.Clauses["WHERE"]; ok {
 		g2.Statement.Clauses["WHERE"] = where
 	}
+	g2.Statement.Unscoped = d.g2.Statement.Unscoped
 	g2.Count(total)
 }
 
+// Aggregate runs the select expressions, e.g. COUNT(*), over the groups of rows
+// sharing the groupBy columns, the groups are ordered by these columns.
+func (d *sqlGenericDao) Aggregate(selects []string, groupBy []string, results *[]map[string]interface{}) error {
+	g2 := d.g2.Select(strings.Join(selects, ", "))
+	if len(groupBy) > 0 {
+		g2 = g2.Group(strings.Join(groupBy, ", ")).Order(strings.Join(groupBy, ", "))
+	}
+	return g2.Find(results).Error
+}
+
 // Gorm finishers (Take, First, Last, etc.) are not idempotent
 // Use a new session to execute these checks
 func (d *sqlGenericDao) Validate(resourceList interface{}) error {
@@ -111,42 +153,123 @@ func (d *sqlGenericDao) Validate(resourceList interface{}) error {
 	return nil
 }
 
+// Purge permanently deletes the records soft-deleted before the given time
+func (d *sqlGenericDao) Purge(deletedBefore time.Time) (int64, error) {
+	result := d.g2.Unscoped().
+		Where(fmt.Sprintf("%s.deleted_at < ?", d.GetTableName()), deletedBefore).
+		Delete(d.g2.Statement.Model)
+	return result.RowsAffected, result.Error
+}
+
+// Transaction runs fn in a single database transaction, the daos given its context join it
+func (d *sqlGenericDao) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return db.Transact(ctx, *d.sessionFactory, fn)
+}
+
 func (d *sqlGenericDao) GetTableName() string {
 	return db.GetTableName(d.g2)
 }
 
+func (d *sqlGenericDao) GetColumnName(field string) (string, bool) {
+	return db.GetColumnName(d.g2, field)
+}
+
+// check the column of the api model, or of the model at the end of the relation path, holds timestamps
+func (d *sqlGenericDao) IsTimestampColumn(relationPath []string, column string) bool {
+	if d.g2.Statement.Parse(d.g2.Statement.Model) != nil {
+		return false
+	}
+
+	modelSchema := d.g2.Statement.Schema
+	for _, fieldName := range relationPath {
+		relationship := findRelationship(modelSchema, fieldName)
+		if relationship == nil {
+			return false
+		}
+		modelSchema = relationship.FieldSchema
+	}
+	field := modelSchema.LookUpField(column)
+	return field != nil && field.DataType == schema.Time
+}
+
 // extract the relation from the api model
 func (d *sqlGenericDao) GetTableRelation(fieldName string) (TableRelation, bool) {
-	// try singular
-	fieldName = strings.ToUpper(fieldName[:1]) + fieldName[1:]
-	table := inflection.Singular(fieldName)
-	association := d.g2.Association(table)
-	// the relation must exist in the model
-	if association.Relationship == nil {
-		// try plural
-		table = inflection.Plural(fieldName)
-		association = d.g2.Association(table)
-		if association.Relationship == nil {
-			return TableRelation{}, false
+	relations, ok := d.GetTableRelationPath([]string{fieldName})
+	if !ok {
+		return TableRelation{}, false
+	}
+	return relations[0], true
+}
+
+// follow a chain of relations, e.g. owner.team, from the api model through the related models.
+// the relation of each hop is returned in order, every one joins onto the table of the one before.
+func (d *sqlGenericDao) GetTableRelationPath(fieldNames []string) ([]TableRelation, bool) {
+	return d.relationPath(fieldNames, findRelationship)
+}
+
+// follow a chain of included relations, e.g. owner.teams, from the api model through the related models.
+// gorm preloads every kind of relation, unlike the joins only belongs_to and has_many ones. The ColumnName
+// of each relation is the column its model needs loaded for the relation to be preloaded.
+func (d *sqlGenericDao) GetPreloadRelationPath(fieldNames []string) ([]TableRelation, bool) {
+	return d.relationPath(fieldNames, lookUpRelationship)
+}
+
+func (d *sqlGenericDao) relationPath(fieldNames []string, find func(*schema.Schema, string) *schema.Relationship) ([]TableRelation, bool) {
+	if len(fieldNames) == 0 || d.g2.Statement.Parse(d.g2.Statement.Model) != nil {
+		return nil, false
+	}
+
+	modelSchema := d.g2.Statement.Schema
+	relations := make([]TableRelation, 0, len(fieldNames))
+	for _, fieldName := range fieldNames {
+		relationship := find(modelSchema, fieldName)
+		if relationship == nil {
+			return nil, false
 		}
+
+		// the foreign key of a belongs_to relation is in the table of the model, the others reference its primary key
+		columnName := relationship.References[0].ForeignKey.DBName
+		foreignColumnName := relationship.References[0].PrimaryKey.DBName
+		if relationship.Type != schema.BelongsTo {
+			columnName = relationship.References[0].PrimaryKey.DBName
+			foreignColumnName = relationship.References[0].ForeignKey.DBName
+		}
+
+		relations = append(relations, TableRelation{
+			Name:              relationship.Name,
+			TableName:         relationship.Field.Schema.Table,
+			ForeignTableName:  relationship.FieldSchema.Table,
+			ForeignColumnName: foreignColumnName,
+			ColumnName:        columnName,
+		})
+		modelSchema = relationship.FieldSchema
 	}
+	return relations, true
+}
 
-	if association.Relationship.Type != "belongs_to" && association.Relationship.Type != "has_many" {
-		// we don't use has_one or many_to_many relations
-		return TableRelation{}, false
+// look up the relation which can be joined by the singular or plural form of the field name
+func findRelationship(modelSchema *schema.Schema, fieldName string) *schema.Relationship {
+	relationship := lookUpRelationship(modelSchema, fieldName)
+	if relationship == nil || (relationship.Type != schema.BelongsTo && relationship.Type != schema.HasMany) {
+		// we don't join has_one or many_to_many relations
+		return nil
 	}
+	return relationship
+}
 
-	columnName := association.Relationship.References[0].ForeignKey.DBName
-	foreignColumnName := association.Relationship.References[0].PrimaryKey.DBName
-	if association.Relationship.Type == "has_many" {
-		columnName = association.Relationship.References[0].PrimaryKey.DBName
-		foreignColumnName = association.Relationship.References[0].ForeignKey.DBName
+// look up the relation of any kind by the singular or plural form of the field name
+func lookUpRelationship(modelSchema *schema.Schema, fieldName string) *schema.Relationship {
+	if fieldName == "" {
+		return nil
 	}
 
-	return TableRelation{
-		TableName:         association.Relationship.Field.Schema.Table,
-		ForeignTableName:  association.Relationship.FieldSchema.Table,
-		ForeignColumnName: foreignColumnName,
-		ColumnName:        columnName,
-	}, true
+	// try singular
+	fieldName = strings.ToUpper(fieldName[:1]) + fieldName[1:]
+	relationship := modelSchema.Relationships.Relations[inflection.Singular(fieldName)]
+	// the relation must exist in the model
+	if relationship == nil {
+		// try plural
+		relationship = modelSchema.Relationships.Relations[inflection.Plural(fieldName)]
+	}
+	return relationship
 }
@@ -0,0 +1,71 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+)
+
+type IdempotencyKeyDao interface {
+	// Get finds the key sent by the user for the method and path of the request, unless it expired
+	Get(ctx context.Context, request *api.IdempotencyKey, now time.Time) (*api.IdempotencyKey, error)
+	Create(ctx context.Context, idempotencyKey *api.IdempotencyKey) (*api.IdempotencyKey, error)
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+var _ IdempotencyKeyDao = &sqlIdempotencyKeyDao{}
+
+type sqlIdempotencyKeyDao struct {
+	sessionFactory *db.SessionFactory
+}
+
+func NewIdempotencyKeyDao(sessionFactory *db.SessionFactory) IdempotencyKeyDao {
+	return &sqlIdempotencyKeyDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlIdempotencyKeyDao) Get(ctx context.Context, request *api.IdempotencyKey, now time.Time) (*api.IdempotencyKey, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var idempotencyKey api.IdempotencyKey
+	err := g2.Take(&idempotencyKey, "key = ? AND username = ? AND method = ? AND path = ? AND expires_at > ?",
+		request.Key, request.Username, request.Method, request.Path, now).Error
+	if err != nil {
+		return nil, err
+	}
+	return &idempotencyKey, nil
+}
+
+func (d *sqlIdempotencyKeyDao) Create(ctx context.Context, idempotencyKey *api.IdempotencyKey) (*api.IdempotencyKey, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	// the expired key of an earlier request is replaced, it is kept until the purge job deletes it
+	err := g2.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "key"}, {Name: "username"}, {Name: "method"}, {Name: "path"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"request_hash": idempotencyKey.RequestHash,
+			"status_code":  idempotencyKey.StatusCode,
+			"response":     idempotencyKey.Response,
+			"expires_at":   idempotencyKey.ExpiresAt,
+			"created_at":   time.Now(),
+			"updated_at":   time.Now(),
+		}),
+	}).Omit(clause.Associations).Create(idempotencyKey).Error
+	if err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return idempotencyKey, nil
+}
+
+// DeleteExpired permanently deletes the keys expired before now
+func (d *sqlIdempotencyKeyDao) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	result := g2.Unscoped().Where("expires_at <= ?", now).Delete(&api.IdempotencyKey{})
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
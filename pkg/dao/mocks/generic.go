@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/dao"
 )
@@ -9,12 +10,14 @@ import (
 var _ dao.GenericDao = &genericDaoMock{}
 
 type genericDaoMock struct {
-	preload string
-	orderBy string
-	joins   string
-	group   string
-	wheres  []dao.Where
-	model   interface{}
+	preload  string
+	selects  []string
+	orderBy  string
+	joins    string
+	group    string
+	wheres   []dao.Where
+	model    interface{}
+	unscoped bool
 }
 
 func NewGenericDao() *genericDaoMock {
@@ -28,6 +31,11 @@ func (g *genericDaoMock) Fetch(offset int, limit int, resourceList interface{})
 	return nil
 }
 
+func (g *genericDaoMock) Get(id string, resource interface{}) error {
+	// Mock implementation - does nothing but returns no error
+	return nil
+}
+
 func (g *genericDaoMock) GetInstanceDao(ctx context.Context, model interface{}) dao.GenericDao {
 	return &genericDaoMock{
 		model:  model,
@@ -39,6 +47,10 @@ func (g *genericDaoMock) Preload(preload string) {
 	g.preload = preload
 }
 
+func (g *genericDaoMock) Select(columns []string) {
+	g.selects = columns
+}
+
 func (g *genericDaoMock) OrderBy(orderBy string) {
 	g.orderBy = orderBy
 }
@@ -55,22 +67,61 @@ func (g *genericDaoMock) Where(where dao.Where) {
 	g.wheres = append(g.wheres, where)
 }
 
+func (g *genericDaoMock) Unscoped() {
+	g.unscoped = true
+}
+
 func (g *genericDaoMock) Count(model interface{}, total *int64) {
 	// Mock implementation - sets count to 0
 	*total = 0
 }
 
+func (g *genericDaoMock) Aggregate(selects []string, groupBy []string, results *[]map[string]interface{}) error {
+	// Mock implementation - returns no groups
+	return nil
+}
+
 func (g *genericDaoMock) Validate(resourceList interface{}) error {
 	// Mock implementation - returns no error
 	return nil
 }
 
+func (g *genericDaoMock) Purge(deletedBefore time.Time) (int64, error) {
+	// Mock implementation - purges nothing
+	return 0, nil
+}
+
+func (g *genericDaoMock) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Mock implementation - runs fn without any transaction
+	return fn(ctx)
+}
+
 func (g *genericDaoMock) GetTableName() string {
 	// Mock implementation - returns empty string
 	return ""
 }
 
+func (g *genericDaoMock) GetColumnName(field string) (string, bool) {
+	// Mock implementation - returns no column
+	return "", false
+}
+
+func (g *genericDaoMock) IsTimestampColumn(relationPath []string, column string) bool {
+	// Mock implementation - returns no timestamp column
+	return false
+}
+
 func (g *genericDaoMock) GetTableRelation(fieldName string) (dao.TableRelation, bool) {
 	// Mock implementation - returns empty relation and false
 	return dao.TableRelation{}, false
 }
+
+func (g *genericDaoMock) GetTableRelationPath(fieldNames []string) ([]dao.TableRelation, bool) {
+	// Mock implementation - returns no relations and false
+	return nil, false
+}
+
+func (g *genericDaoMock) GetPreloadRelationPath(fieldNames []string) ([]dao.TableRelation, bool) {
+	// Mock implementation - returns no relations and false
+	return nil, false
+}
@@ -0,0 +1,47 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+)
+
+var _ dao.IdempotencyKeyDao = &idempotencyKeyDaoMock{}
+
+type idempotencyKeyDaoMock struct {
+	idempotencyKeys []*api.IdempotencyKey
+}
+
+func NewIdempotencyKeyDao() *idempotencyKeyDaoMock {
+	return &idempotencyKeyDaoMock{}
+}
+
+func (d *idempotencyKeyDaoMock) Get(ctx context.Context, request *api.IdempotencyKey, now time.Time) (*api.IdempotencyKey, error) {
+	for _, k := range d.idempotencyKeys {
+		if k.Key == request.Key && k.Username == request.Username && k.Method == request.Method && k.Path == request.Path && k.ExpiresAt.After(now) {
+			return k, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (d *idempotencyKeyDaoMock) Create(ctx context.Context, idempotencyKey *api.IdempotencyKey) (*api.IdempotencyKey, error) {
+	d.idempotencyKeys = append(d.idempotencyKeys, idempotencyKey)
+	return idempotencyKey, nil
+}
+
+func (d *idempotencyKeyDaoMock) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	kept := []*api.IdempotencyKey{}
+	for _, k := range d.idempotencyKeys {
+		if k.ExpiresAt.After(now) {
+			kept = append(kept, k)
+		}
+	}
+	deleted := int64(len(d.idempotencyKeys) - len(kept))
+	d.idempotencyKeys = kept
+	return deleted, nil
+}
@@ -19,8 +19,10 @@ type (
 )
 
 const (
-	Migrations LockType = "migrations"
-	Events     LockType = "events"
+	Migrations      LockType = "migrations"
+	Events          LockType = "events"
+	Purge           LockType = "purge"
+	IdempotencyKeys LockType = "idempotency_keys"
 )
 
 // LockFactory provides the blocking/unblocking locks based on PostgreSQL advisory lock.
@@ -163,6 +165,10 @@ func (f *AdvisoryLockFactory) Unlock(ctx context.Context, uuid string) {
 //	select pg_advisory_xact_lock(id, lockType)  # obtain the lock (blocking)
 //	end                                         # end the Tx and release the lock
 //
+// When the context runs in a database transaction, see Transact, the lock is obtained in that transaction
+// instead and released when it ends, so that the changes made under the lock are committed before another
+// transaction can obtain it.
+//
 // UUID is a way to own the lock. Only the very first
 // service call that owns the lock will have the correct UUID. This is necessary
 // to allow functions to call other service functions as part of the same lock (id, lockType).
@@ -173,11 +179,24 @@ type AdvisoryLock struct {
 	id        *string
 	lockType  *LockType
 	startTime time.Time
+	// inTransaction is set for the locks obtained in the transaction of the context, which unlock doesn't end
+	inTransaction bool
 }
 
 // newAdvisoryLock constructs a new AdvisoryLock object.
 func newAdvisoryLock(ctx context.Context, connection SessionFactory) (*AdvisoryLock, error) {
-	// it requires a new DB session to start the advisory lock.
+	// the lock of a context running in a transaction is held until the transaction ends
+	if session, ok := TransactionSession(ctx); ok {
+		var txid struct{ ID int64 }
+		session.Raw("select txid_current() as id").Scan(&txid)
+		return &AdvisoryLock{
+			txid:          txid.ID,
+			g2:            session,
+			startTime:     time.Now(),
+			inTransaction: true,
+		}, nil
+	}
+
 	g2 := connection.New(ctx)
 
 	// start a Tx to ensure gorm will obtain/release the lock using a same connection.
@@ -252,8 +271,12 @@ func (l *AdvisoryLock) unlock() error {
 		return errors.New("AdvisoryLock: transaction is missing")
 	}
 
-	// it ends the Tx and implicitly releases the lock.
-	err := l.g2.Commit().Error
+	// it ends the Tx and implicitly releases the lock, the transaction of the context releases its own locks
+	// when it ends.
+	var err error
+	if !l.inTransaction {
+		err = l.g2.Commit().Error
+	}
 	l.g2 = nil
 	l.uuid = nil
 	l.id = nil
@@ -5,6 +5,8 @@ package db_context
 import (
 	"context"
 
+	"gorm.io/gorm"
+
 	"github.com/openshift-online/rh-trex-ai/pkg/db/transaction"
 )
 
@@ -12,6 +14,8 @@ type contextKey int
 
 const (
 	transactionKey contextKey = iota
+	sessionKey
+	dryRunKey
 )
 
 // WithTransaction adds the transaction to the context and returns a new context
@@ -33,3 +37,26 @@ func TxID(ctx context.Context) (id int64, ok bool) {
 	}
 	return tx.TxID(), true
 }
+
+// WithSession adds the gorm session of a database transaction to the context, the sessions
+// created for the context join the transaction
+func WithSession(ctx context.Context, g2 *gorm.DB) context.Context {
+	return context.WithValue(ctx, sessionKey, g2)
+}
+
+// Session extracts the gorm session of the database transaction from the context
+func Session(ctx context.Context) (g2 *gorm.DB, ok bool) {
+	g2, ok = ctx.Value(sessionKey).(*gorm.DB)
+	return g2, ok && g2 != nil
+}
+
+// WithDryRun marks the context as the one of a dry run, whose changes are always rolled back
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey, true)
+}
+
+// DryRun returns true if the context is the one of a dry run
+func DryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey).(bool)
+	return dryRun
+}
@@ -140,6 +140,9 @@ func (f *Default) NewListener(ctx context.Context, channel string, callback func
 }
 
 func (f *Default) New(ctx context.Context) *gorm.DB {
+	if tx, ok := db.TransactionSession(ctx); ok {
+		return tx
+	}
 	conn := f.g2.Session(&gorm.Session{
 		Context: ctx,
 		Logger:  f.g2.Logger.LogMode(logger.Silent),
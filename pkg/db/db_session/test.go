@@ -187,6 +187,9 @@ func (f *Test) DirectDB() *sql.DB {
 }
 
 func (f *Test) New(ctx context.Context) *gorm.DB {
+	if tx, ok := db.TransactionSession(ctx); ok {
+		return tx
+	}
 	if f.wasDisconnected {
 		// Connection was killed in order to reset DB
 		f.db, f.g2 = connectFactory(f.config)
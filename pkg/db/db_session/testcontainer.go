@@ -111,6 +111,9 @@ func (f *Testcontainer) DirectDB() *sql.DB {
 }
 
 func (f *Testcontainer) New(ctx context.Context) *gorm.DB {
+	if tx, ok := db.TransactionSession(ctx); ok {
+		return tx
+	}
 	conn := f.g2.Session(&gorm.Session{
 		Context: ctx,
 		Logger:  f.g2.Logger.LogMode(logger.Silent),
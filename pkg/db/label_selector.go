@@ -0,0 +1,154 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+// LabelsColumn is the JSONB column holding the labels of the kinds embedding api.Labeled
+const LabelsColumn = "labels"
+
+// LabelsIndexName returns the name of the GIN index which backs label selectors on a table.
+func LabelsIndexName(table string) string {
+	return fmt.Sprintf("idx_%s_labels", table)
+}
+
+// LabelsIndexSQL returns the statement creating the GIN index which backs label selectors on a table.
+func LabelsIndexSQL(table string) string {
+	return fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s USING GIN (%s)", LabelsIndexName(table), table, LabelsColumn)
+}
+
+// AddLabelsSQL returns the statement adding the labels column to an existing table.
+func AddLabelsSQL(table string) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s jsonb NOT NULL DEFAULT '{}'", table, LabelsColumn)
+}
+
+// The requirements of a label selector, e.g. env=prod,tier in (web,api),!canary
+var (
+	labelExistsRegex   = regexp.MustCompile(`^(!?)\s*([^\s!=(),]+)$`)
+	labelEqualityRegex = regexp.MustCompile(`^([^\s!=(),]+)\s*(==|=|!=)\s*([^\s!=(),]*)$`)
+	labelSetRegex      = regexp.MustCompile(`^([^\s!=(),]+)\s+(in|notin)\s*\(([^()]*)\)$`)
+)
+
+// LabelSelectorSqlizer converts a Kubernetes style label selector into a SQL filter on the labels column.
+// The requirements are separated by commas and all must match:
+//
+//	key=value, key==value  the label is set to the value
+//	key!=value             the label is not set to the value, or not set at all
+//	key in (v1,v2)         the label is set to one of the values
+//	key notin (v1,v2)      the label is set to none of the values, or not set at all
+//	key, !key              the label is set, whatever its value, or not set
+//
+// Equality and set requirements are checked by JSONB containment, which a GIN index on the column serves.
+func LabelSelectorSqlizer(column string, selector string) (squirrel.Sqlizer, *errors.ServiceError) {
+	requirements, err := splitLabelSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := squirrel.And{}
+	for _, requirement := range requirements {
+		var s squirrel.Sqlizer
+		var serviceErr *errors.ServiceError
+		switch {
+		case labelSetRegex.MatchString(requirement):
+			match := labelSetRegex.FindStringSubmatch(requirement)
+			var values []string
+			for _, value := range strings.Split(match[3], ",") {
+				if value = strings.TrimSpace(value); value != "" {
+					values = append(values, value)
+				}
+			}
+			if len(values) == 0 {
+				return nil, errors.BadRequest("Failed to parse label selector: %s must be given values", requirement)
+			}
+			s, serviceErr = labelContains(column, match[1], values, match[2] == "notin")
+		case labelEqualityRegex.MatchString(requirement):
+			match := labelEqualityRegex.FindStringSubmatch(requirement)
+			s, serviceErr = labelContains(column, match[1], []string{match[3]}, match[2] == "!=")
+		case labelExistsRegex.MatchString(requirement):
+			match := labelExistsRegex.FindStringSubmatch(requirement)
+			if err := api.ValidateLabelKey(match[2]); err != nil {
+				return nil, errors.BadRequest("Failed to parse label selector: %s", err.Error())
+			}
+			if match[1] == "!" {
+				s = squirrel.Expr(fmt.Sprintf("%s ->> ? IS NULL", column), match[2])
+			} else {
+				s = squirrel.Expr(fmt.Sprintf("%s ->> ? IS NOT NULL", column), match[2])
+			}
+		default:
+			return nil, errors.BadRequest("Failed to parse label selector: %s", requirement)
+		}
+		if serviceErr != nil {
+			return nil, serviceErr
+		}
+		filter = append(filter, s)
+	}
+	return filter, nil
+}
+
+// labelContains matches the labels containing the key set to any of the values, or to none of them when negated
+func labelContains(column, key string, values []string, negate bool) (squirrel.Sqlizer, *errors.ServiceError) {
+	if err := api.ValidateLabelKey(key); err != nil {
+		return nil, errors.BadRequest("Failed to parse label selector: %s", err.Error())
+	}
+	matches := make([]string, 0, len(values))
+	args := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		if err := api.ValidateLabelValue(value); err != nil {
+			return nil, errors.BadRequest("Failed to parse label selector: %s", err.Error())
+		}
+		contained, err := json.Marshal(map[string]string{key: value})
+		if err != nil {
+			return nil, errors.GeneralError("Unable to select labels: %s", err)
+		}
+		matches = append(matches, fmt.Sprintf("%s @> ?::jsonb", column))
+		args = append(args, string(contained))
+	}
+	sql := strings.Join(matches, " OR ")
+	if negate {
+		sql = fmt.Sprintf("NOT (%s)", sql)
+	} else if len(matches) > 1 {
+		sql = fmt.Sprintf("(%s)", sql)
+	}
+	return squirrel.Expr(sql, args...), nil
+}
+
+// splitLabelSelector splits a selector into its requirements, the commas within the values of a set are kept
+func splitLabelSelector(selector string) ([]string, *errors.ServiceError) {
+	var requirements []string
+	depth, start := 0, 0
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				requirements = append(requirements, strings.TrimSpace(selector[start:i]))
+				start = i + 1
+			}
+		}
+		if depth < 0 || depth > 1 {
+			return nil, errors.BadRequest("Failed to parse label selector: unbalanced parentheses in %s", selector)
+		}
+	}
+	if depth != 0 {
+		return nil, errors.BadRequest("Failed to parse label selector: unbalanced parentheses in %s", selector)
+	}
+	requirements = append(requirements, strings.TrimSpace(selector[start:]))
+	for _, requirement := range requirements {
+		if requirement == "" {
+			return nil, errors.BadRequest("Failed to parse label selector: empty requirement in %s", selector)
+		}
+	}
+	return requirements, nil
+}
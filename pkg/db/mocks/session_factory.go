@@ -47,6 +47,11 @@ func NewMockSessionFactory() *MockSessionFactory {
 	}
 }
 
+// Mock gives access to the sqlmock behind the factory, to set up the expected queries and their rows.
+func (m *MockSessionFactory) Mock() sqlmock.Sqlmock {
+	return m.mock
+}
+
 func (m *MockSessionFactory) Init(config *config.DatabaseConfig) {
 	// Mock implementation - does nothing
 }
@@ -56,6 +61,9 @@ func (m *MockSessionFactory) DirectDB() *sql.DB {
 }
 
 func (m *MockSessionFactory) New(ctx context.Context) *gorm.DB {
+	if tx, ok := db.TransactionSession(ctx); ok {
+		return tx
+	}
 	return m.gormDB.WithContext(ctx)
 }
 
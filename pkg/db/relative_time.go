@@ -0,0 +1,150 @@
+package db
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+// A relative time is `now` or `today` (midnight UTC), optionally shifted by a chain of
+// offsets, e.g. now-24h, today-7d or now-1d+12h. Offsets are in s(econds), m(inutes),
+// h(ours), d(ays) or w(eeks).
+var (
+	relativeTimeRegex       = regexp.MustCompile(`^(now|today)((?:[+-]\d+[smhdw])*)$`)
+	relativeTimeOffsetRegex = regexp.MustCompile(`([+-])(\d+)([smhdw])`)
+	bareRelativeTimeRegex   = regexp.MustCompile(`(^|[^\w.])((?:now|today)(?:[+-]\d+[smhdw])*)\b`)
+)
+
+// relativeTimeMarker prefixes the relative times QuoteRelativeTimes turned into strings,
+// it tells them apart from strings the search compares with.
+const relativeTimeMarker = "\x00"
+
+var relativeTimeUnits = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+}
+
+// QuoteRelativeTimes quotes the relative times written as is in a search, e.g. ( created_at > now-24h ),
+// since the tree-search-language parser only takes numbers and strings as values.
+func QuoteRelativeTimes(search string) string {
+	// splitting on quotes leaves the text outside of strings at the even indexes
+	parts := strings.Split(search, "'")
+	for i := 0; i < len(parts); i += 2 {
+		parts[i] = bareRelativeTimeRegex.ReplaceAllString(parts[i], "$1'"+relativeTimeMarker+"$2'")
+	}
+	return strings.Join(parts, "'")
+}
+
+// ParseRelativeTime evaluates a relative time, e.g. now-24h, at the time now.
+func ParseRelativeTime(expression string, now time.Time) (time.Time, error) {
+	match := relativeTimeRegex.FindStringSubmatch(expression)
+	if match == nil {
+		return time.Time{}, fmt.Errorf("%s is not a relative time", expression)
+	}
+
+	t := now
+	if match[1] == "today" {
+		t = now.UTC().Truncate(24 * time.Hour)
+	}
+	for _, offset := range relativeTimeOffsetRegex.FindAllStringSubmatch(match[2], -1) {
+		amount, err := strconv.Atoi(offset[2])
+		if err != nil || amount > math.MaxInt32 {
+			return time.Time{}, fmt.Errorf("%s is out of range", expression)
+		}
+		if offset[1] == "-" {
+			amount = -amount
+		}
+		switch offset[3] {
+		case "d":
+			t = t.AddDate(0, 0, amount)
+		case "w":
+			t = t.AddDate(0, 0, 7*amount)
+		default:
+			t = t.Add(time.Duration(amount) * relativeTimeUnits[offset[3]])
+		}
+	}
+	return t, nil
+}
+
+// RelativeTimeWalk walks on the filter tree and replaces the relative times compared with
+// timestamp fields by the absolute time they stand for at the time now.
+// Relative times written as is in the search apply to timestamp fields only.
+func RelativeTimeWalk(n tsl.Node, isTimestamp func(field string) bool, now time.Time) (tsl.Node, *errors.ServiceError) {
+	l, ok := n.Left.(tsl.Node)
+	if !ok {
+		// This is a leaf, just return.
+		return n, nil
+	}
+
+	// If the left hand side is an identifier, its values are on the right hand side.
+	if l.Func == tsl.IdentOp {
+		right, err := relativeTimeStep(n.Right, l.Left.(string), isTimestamp, now)
+		if err != nil {
+			return n, err
+		}
+		return tsl.Node{Func: n.Func, Left: l, Right: right}, nil
+	}
+
+	// o/w continue walking the tree.
+	left, err := RelativeTimeWalk(l, isTimestamp, now)
+	if err != nil {
+		return n, err
+	}
+	var right interface{} = n.Right
+	if r, ok := n.Right.(tsl.Node); ok {
+		if right, err = RelativeTimeWalk(r, isTimestamp, now); err != nil {
+			return n, err
+		}
+	}
+	return tsl.Node{Func: n.Func, Left: left, Right: right}, nil
+}
+
+// relativeTimeStep replaces the relative times among the values compared with field.
+func relativeTimeStep(values interface{}, field string, isTimestamp func(field string) bool, now time.Time) (interface{}, *errors.ServiceError) {
+	switch v := values.(type) {
+	case tsl.Node:
+		switch v.Func {
+		case tsl.StringOp:
+			value := v.Left.(string)
+			expression, quoted := strings.CutPrefix(value, relativeTimeMarker)
+			if !quoted && !(relativeTimeRegex.MatchString(value) && isTimestamp(field)) {
+				// a string, not a relative time
+				return v, nil
+			}
+			if !isTimestamp(field) {
+				return v, errors.BadRequest("%s is not a timestamp field, it cannot be compared with %s", field, expression)
+			}
+			t, err := ParseRelativeTime(expression, now)
+			if err != nil {
+				return v, errors.BadRequest("%s", err.Error())
+			}
+			return tsl.Node{Func: tsl.StringOp, Left: t.UTC().Format(time.RFC3339Nano)}, nil
+		case tsl.ArrayOp:
+			// e.g. the values of IN and BETWEEN
+			items, err := relativeTimeStep(v.Right, field, isTimestamp, now)
+			if err != nil {
+				return v, err
+			}
+			return tsl.Node{Func: v.Func, Left: v.Left, Right: items}, nil
+		}
+	case []tsl.Node:
+		items := make([]tsl.Node, len(v))
+		for i, item := range v {
+			replaced, err := relativeTimeStep(item, field, isTimestamp, now)
+			if err != nil {
+				return v, err
+			}
+			items[i] = replaced.(tsl.Node)
+		}
+		return items, nil
+	}
+	return values, nil
+}
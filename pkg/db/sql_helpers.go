@@ -236,6 +236,24 @@ func ArgsToOrderBy(
 	return
 }
 
+// GetColumnName maps a json field name onto a column of the model behind g2.
+// Fields with a json tag are matched by that name, all others by their column name.
+func GetColumnName(g2 *gorm.DB, name string) (string, bool) {
+	if g2.Statement.Parse(g2.Statement.Model) != nil || g2.Statement.Schema == nil {
+		return "", false
+	}
+	for _, field := range g2.Statement.Schema.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+		if jsonName == name || (jsonName == "" && field.DBName == name) {
+			return field.DBName, true
+		}
+	}
+	return "", false
+}
+
 func GetTableName(g2 *gorm.DB) string {
 	if g2.Statement.Parse(g2.Statement.Model) != nil {
 		return "xxx"
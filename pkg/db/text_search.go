@@ -0,0 +1,105 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	sqlFilter "github.com/yaacov/tree-search-language/pkg/walkers/sql"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+// TextSearchConfig is the postgres text search configuration of full-text search.
+// A GIN index only serves the search when it is built with the same configuration.
+const TextSearchConfig = "english"
+
+// TextSearchVector returns the document a column is matched against in full-text search.
+func TextSearchVector(column string) string {
+	return fmt.Sprintf("to_tsvector('%s', %s)", TextSearchConfig, column)
+}
+
+// TextSearchIndexName returns the name of the GIN index which backs full-text search on a column.
+func TextSearchIndexName(table, column string) string {
+	return fmt.Sprintf("idx_%s_%s_text_search", table, column)
+}
+
+// TextSearchIndexSQL returns the statement creating the GIN index which backs full-text search on a column.
+func TextSearchIndexSQL(table, column string) string {
+	return fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s USING GIN (%s)",
+		TextSearchIndexName(table, column), table, TextSearchVector(column))
+}
+
+// notExpr negates a filter, the one of the tree-search-language sql walker is not exported.
+type notExpr []squirrel.Sqlizer
+
+func (n notExpr) ToSql() (string, []interface{}, error) {
+	sql, args, err := n[0].ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("NOT (%s)", sql), args, nil
+}
+
+// SqlizerWalk converts the search tree into a SQL filter, the same as the tree-search-language
+// sql walker does. In addition it translates the full-text operator, e.g. ( species ~= 'big teeth' ),
+// into a match of plainto_tsquery against the document of the field. The field must be one of
+// textSearchFields. The relevance of every full-text match is returned too, to order the results by.
+func SqlizerWalk(n tsl.Node, textSearchFields map[string]bool) (s squirrel.Sqlizer, relevance []string, err *errors.ServiceError) {
+	switch n.Func {
+	case tsl.AndOp, tsl.OrOp:
+		var l, r squirrel.Sqlizer
+		var lRelevance, rRelevance []string
+		if l, lRelevance, err = SqlizerWalk(n.Left.(tsl.Node), textSearchFields); err != nil {
+			return
+		}
+		if r, rRelevance, err = SqlizerWalk(n.Right.(tsl.Node), textSearchFields); err != nil {
+			return
+		}
+		if n.Func == tsl.AndOp {
+			s = squirrel.And{l, r}
+		} else {
+			s = squirrel.Or{l, r}
+		}
+		relevance = append(lRelevance, rRelevance...)
+	case tsl.NotOp:
+		// a negated match tells nothing about the relevance of the results
+		var l squirrel.Sqlizer
+		if l, _, err = SqlizerWalk(n.Left.(tsl.Node), textSearchFields); err != nil {
+			return
+		}
+		s = notExpr{l}
+	case tsl.RegexOp:
+		return textSearchStep(n, textSearchFields)
+	default:
+		var walkErr error
+		if s, walkErr = sqlFilter.Walk(n); walkErr != nil {
+			err = errors.BadRequest("%s", walkErr.Error())
+		}
+	}
+	return
+}
+
+// textSearchStep handles the full-text operator for SqlizerWalk.
+func textSearchStep(n tsl.Node, textSearchFields map[string]bool) (squirrel.Sqlizer, []string, *errors.ServiceError) {
+	l, ok := n.Left.(tsl.Node)
+	if !ok || l.Func != tsl.IdentOp {
+		return nil, nil, errors.BadRequest("full-text search must be applied on a field")
+	}
+	field := l.Left.(string)
+	if !textSearchFields[field] {
+		return nil, nil, errors.BadRequest("%s does not support full-text search", field)
+	}
+	r, ok := n.Right.(tsl.Node)
+	if !ok || r.Func != tsl.StringOp {
+		return nil, nil, errors.BadRequest("full-text search on %s must be given a string", field)
+	}
+	text := r.Left.(string)
+
+	vector := TextSearchVector(field)
+	match := squirrel.Expr(fmt.Sprintf("%s @@ plainto_tsquery('%s', ?)", vector, TextSearchConfig), text)
+	// ORDER BY takes no bind variables, the text is quoted instead
+	relevance := fmt.Sprintf("ts_rank(%s, plainto_tsquery('%s', %s))", vector, TextSearchConfig, pq.QuoteLiteral(text))
+	return match, []string{relevance}, nil
+}
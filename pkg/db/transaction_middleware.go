@@ -1,9 +1,10 @@
 package db
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/getsentry/sentry-go"
 	"github.com/openshift-online/rh-trex-ai/pkg/db/db_context"
@@ -12,6 +13,9 @@ import (
 	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 )
 
+// DryRunParam is the query parameter which asks a mutating request to be run without persisting anything
+const DryRunParam = "dryRun"
+
 // TransactionMiddleware creates a new HTTP middleware that begins a database transaction
 // and stores it in the request context.
 func TransactionMiddleware(next http.Handler, connection SessionFactory) http.Handler {
@@ -20,11 +24,11 @@ func TransactionMiddleware(next http.Handler, connection SessionFactory) http.Ha
 		ctx, err := NewContext(r.Context(), connection)
 		log := logger.NewOCMLogger(ctx)
 		if err != nil {
-			log.Extra("error", err.Error()).Error("Could not create transaction")
+			log.Extra("error", err.Error()).WithError(err).Error("Could not create transaction")
 			// use default error to avoid exposing internals to users
 			err := errors.GeneralError("")
 			operationID := logger.GetOperationID(ctx)
-			writeJSONResponse(w, err.HttpCode, err.AsOpenapiError(operationID))
+			err.WriteResponse(ctx, w, operationID)
 			return
 		}
 
@@ -43,17 +47,44 @@ func TransactionMiddleware(next http.Handler, connection SessionFactory) http.Ha
 		// Returned from handlers and resolve transactions.
 		defer func() { Resolve(r.Context()) }()
 
+		if dryRunRequested(r) {
+			serveDryRun(w, r, next, connection)
+			return
+		}
+
 		// Continue handling requests.
 		next.ServeHTTP(w, r)
 	})
 }
 
-func writeJSONResponse(w http.ResponseWriter, code int, payload interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
+// dryRunRequested returns true for the mutating requests sent with dryRun=true
+func dryRunRequested(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return false
+	}
+	dryRun, err := strconv.ParseBool(r.URL.Query().Get(DryRunParam))
+	return err == nil && dryRun
+}
 
-	if payload != nil {
-		response, _ := json.Marshal(payload)
-		_, _ = w.Write(response)
+// serveDryRun handles the request in a transaction which is always rolled back, so that the validation
+// and the constraints of the database apply to it but none of its changes, events included, is persisted
+func serveDryRun(w http.ResponseWriter, r *http.Request, next http.Handler, connection SessionFactory) {
+	served := false
+	err := DryRun(r.Context(), connection, func(ctx context.Context) error {
+		*r = *r.WithContext(ctx)
+		served = true
+		next.ServeHTTP(w, r)
+		return nil
+	})
+	if err != nil {
+		log := logger.NewOCMLogger(r.Context())
+		log.Extra("error", err.Error()).WithError(err).Error("Could not run dry run transaction")
+		if !served {
+			// use default error to avoid exposing internals to users
+			err := errors.GeneralError("")
+			err.WriteResponse(r.Context(), w, logger.GetOperationID(r.Context()))
+		}
 	}
 }
@@ -2,7 +2,11 @@ package db
 
 import (
 	"context"
+	"errors"
 
+	"gorm.io/gorm"
+
+	dbContext "github.com/openshift-online/rh-trex-ai/pkg/db/db_context"
 	"github.com/openshift-online/rh-trex-ai/pkg/db/transaction"
 )
 
@@ -36,3 +40,41 @@ func newTransaction(ctx context.Context, connection SessionFactory) (*transactio
 
 	return transaction.Build(tx, txid, defaultRollbackPolicy), nil
 }
+
+// Transact runs fn in a single database transaction, committed when fn succeeds and rolled back when it fails.
+// The sessions created for the context given to fn join the transaction, nested calls run in savepoints.
+func Transact(ctx context.Context, connection SessionFactory, fn func(ctx context.Context) error) error {
+	return connection.New(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(dbContext.WithSession(ctx, tx))
+	})
+}
+
+// errDryRun rolls back the transaction of a dry run
+var errDryRun = errors.New("dry run")
+
+// DryRun runs fn in a single database transaction like Transact, but always rolls it back.
+// The context given to fn is marked as the one of a dry run, see dbContext.DryRun.
+func DryRun(ctx context.Context, connection SessionFactory, fn func(ctx context.Context) error) error {
+	var fnErr error
+	err := Transact(ctx, connection, func(ctx context.Context) error {
+		fnErr = fn(dbContext.WithDryRun(ctx))
+		return errDryRun
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != errDryRun {
+		return err
+	}
+	return nil
+}
+
+// TransactionSession returns a session of the transaction Transact runs the context in, if any.
+// The session factories return it so that the statements run for the context join the transaction.
+func TransactionSession(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := dbContext.Session(ctx)
+	if !ok {
+		return nil, false
+	}
+	return tx.Session(&gorm.Session{Context: ctx}), true
+}
@@ -27,6 +27,8 @@ func (e *IntegrationTestingEnvImpl) OverrideConfig(c *config.ApplicationConfig)
 	if os.Getenv("DB_DEBUG") == "true" {
 		c.Database.Debug = true
 	}
+	// the tests fail on the responses which don't match the OpenAPI specification
+	c.Server.EnableResponseValidation = true
 	return nil
 }
 
@@ -53,5 +55,6 @@ func (e *IntegrationTestingEnvImpl) Flags() map[string]string {
 		"ocm-debug":            "false",
 		"enable-ocm-mock":      "true",
 		"enable-sentry":        "false",
+		"create-on-put-kinds":  "Dinosaur",
 	}
 }
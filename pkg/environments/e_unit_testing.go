@@ -22,6 +22,8 @@ func (e *UnitTestingEnvImpl) OverrideConfig(c *config.ApplicationConfig) error {
 	if os.Getenv("DB_DEBUG") == "true" {
 		c.Database.Debug = true
 	}
+	// the tests fail on the responses which don't match the OpenAPI specification
+	c.Server.EnableResponseValidation = true
 	return nil
 }
 
@@ -3,6 +3,7 @@ package errors
 import (
 	"fmt"
 	"net/http"
+	"runtime"
 	"strconv"
 
 	"github.com/golang/glog"
@@ -10,6 +11,9 @@ import (
 	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
 )
 
+// maxStackDepth is the number of calls WithStack captures at most
+const maxStackDepth = 32
+
 var (
 	errorCodePrefix = "rh-trex"
 	errorHref       = "/api/rh-trex/v1/errors/"
@@ -60,6 +64,9 @@ const (
 
 	// DatabaseAdvisoryLock occurs whe the advisory lock is failed to get
 	ErrorDatabaseAdvisoryLock ServiceErrorCode = 26
+
+	// IdempotencyKeyReused occurs when an Idempotency-Key is sent again with a different request
+	ErrorIdempotencyKeyReused ServiceErrorCode = 27
 )
 
 type ServiceErrorCode int
@@ -75,24 +82,52 @@ func Find(code ServiceErrorCode) (bool, *ServiceError) {
 	return false, nil
 }
 
-func Errors() ServiceErrors {
-	return ServiceErrors{
-		ServiceError{ErrorInvalidToken, "Invalid token provided", http.StatusForbidden},
-		ServiceError{ErrorForbidden, "Forbidden to perform this action", http.StatusForbidden},
-		ServiceError{ErrorConflict, "An entity with the specified unique values already exists", http.StatusConflict},
-		ServiceError{ErrorNotFound, "Resource not found", http.StatusNotFound},
-		ServiceError{ErrorValidation, "General validation failure", http.StatusBadRequest},
-		ServiceError{ErrorGeneral, "Unspecified error", http.StatusInternalServerError},
-		ServiceError{ErrorNotImplemented, "HTTP Method not implemented for this endpoint", http.StatusMethodNotAllowed},
-		ServiceError{ErrorUnauthorized, "Account is unauthorized to perform this action", http.StatusForbidden},
-		ServiceError{ErrorUnauthenticated, "Account authentication could not be verified", http.StatusUnauthorized},
-		ServiceError{ErrorMalformedRequest, "Unable to read request body", http.StatusBadRequest},
-		ServiceError{ErrorBadRequest, "Bad request", http.StatusBadRequest},
-		ServiceError{ErrorFailedToParseSearch, "Failed to parse search query", http.StatusBadRequest},
-		ServiceError{ErrorDatabaseAdvisoryLock, "Database advisory lock error", http.StatusInternalServerError},
+// MinRegisteredCode is the first code of the registered errors, the ones below are kept for the built-in errors
+const MinRegisteredCode ServiceErrorCode = 1000
+
+// registered are the errors of the plugins, see Register
+var registered ServiceErrors
+
+// Register adds errors with their own codes, reasons and HTTP codes to the built-in ones, for the plugins
+// to respond with errors of their domain; they are found by Find and New and listed in the error catalog.
+// It is meant to be called from the init functions of the plugins and panics, stopping the service at
+// startup, when a code is below MinRegisteredCode or already used, or when the HTTP code isn't an error one.
+func Register(errs ...ServiceError) {
+	for _, err := range errs {
+		if err.Code < MinRegisteredCode {
+			panic(fmt.Sprintf("error code %d of '%s' is kept for the built-in errors, the codes start at %d",
+				err.Code, err.Reason, MinRegisteredCode))
+		}
+		if exists, used := Find(err.Code); exists {
+			panic(fmt.Sprintf("error code %d of '%s' is already used by '%s'", err.Code, err.Reason, used.Reason))
+		}
+		if err.HttpCode < http.StatusBadRequest || err.HttpCode > 599 {
+			panic(fmt.Sprintf("error code %d of '%s' has HTTP code %d, which isn't an error one", err.Code, err.Reason, err.HttpCode))
+		}
+		registered = append(registered, err)
 	}
 }
 
+// Errors returns the built-in errors followed by the registered ones
+func Errors() ServiceErrors {
+	return append(ServiceErrors{
+		ServiceError{Code: ErrorInvalidToken, Reason: "Invalid token provided", HttpCode: http.StatusForbidden},
+		ServiceError{Code: ErrorForbidden, Reason: "Forbidden to perform this action", HttpCode: http.StatusForbidden},
+		ServiceError{Code: ErrorConflict, Reason: "An entity with the specified unique values already exists", HttpCode: http.StatusConflict},
+		ServiceError{Code: ErrorNotFound, Reason: "Resource not found", HttpCode: http.StatusNotFound},
+		ServiceError{Code: ErrorValidation, Reason: "General validation failure", HttpCode: http.StatusBadRequest},
+		ServiceError{Code: ErrorGeneral, Reason: "Unspecified error", HttpCode: http.StatusInternalServerError},
+		ServiceError{Code: ErrorNotImplemented, Reason: "HTTP Method not implemented for this endpoint", HttpCode: http.StatusMethodNotAllowed},
+		ServiceError{Code: ErrorUnauthorized, Reason: "Account is unauthorized to perform this action", HttpCode: http.StatusForbidden},
+		ServiceError{Code: ErrorUnauthenticated, Reason: "Account authentication could not be verified", HttpCode: http.StatusUnauthorized},
+		ServiceError{Code: ErrorMalformedRequest, Reason: "Unable to read request body", HttpCode: http.StatusBadRequest},
+		ServiceError{Code: ErrorBadRequest, Reason: "Bad request", HttpCode: http.StatusBadRequest},
+		ServiceError{Code: ErrorFailedToParseSearch, Reason: "Failed to parse search query", HttpCode: http.StatusBadRequest},
+		ServiceError{Code: ErrorDatabaseAdvisoryLock, Reason: "Database advisory lock error", HttpCode: http.StatusInternalServerError},
+		ServiceError{Code: ErrorIdempotencyKeyReused, Reason: "Idempotency key was used for a different request", HttpCode: http.StatusUnprocessableEntity},
+	}, registered...)
+}
+
 type ServiceError struct {
 	// Code is the numeric and distinct ID for the error
 	Code ServiceErrorCode
@@ -100,6 +135,22 @@ type ServiceError struct {
 	Reason string
 	// HttopCode is the HttpCode associated with the error when the error is returned as an API response
 	HttpCode int
+	// Details are the fields of the request which caused the error, if any
+	Details []FieldViolation
+	// cause is the underlying error, reported in the logs and to Sentry but never sent to the clients
+	cause error
+	// stack holds the program counters of the calls which led to the error, when captured
+	stack []uintptr
+}
+
+// FieldViolation describes a field of the request which failed a validation rule
+type FieldViolation struct {
+	// Field is the path of the field, like `labels.era` or `items[0].species`
+	Field string
+	// Rule is the name of the rule the field failed, like `required` or `unique`
+	Rule string
+	// Message is the human readable description of the failure
+	Message string
 }
 
 // New Reason can be a string with format verbs, which will be replace by the specified values
@@ -109,7 +160,7 @@ func New(code ServiceErrorCode, reason string, values ...interface{}) *ServiceEr
 	exists, err := Find(code)
 	if !exists {
 		glog.Errorf("Undefined error code used: %d", code)
-		err = &ServiceError{ErrorGeneral, "Unspecified error", 500}
+		err = &ServiceError{Code: ErrorGeneral, Reason: "Unspecified error", HttpCode: 500}
 	}
 
 	// If the reason is unspecified, use the default
@@ -120,12 +171,49 @@ func New(code ServiceErrorCode, reason string, values ...interface{}) *ServiceEr
 	return err
 }
 
+// WithDetails adds field violations to the error and returns it
+func (e *ServiceError) WithDetails(details ...FieldViolation) *ServiceError {
+	e.Details = append(e.Details, details...)
+	return e
+}
+
+// WithCause wraps the underlying error, for errors.Is and errors.As to find it and the logs to report it,
+// the responses only hold the reason
+func (e *ServiceError) WithCause(cause error) *ServiceError {
+	e.cause = cause
+	return e
+}
+
+// WithStack captures the stack of the caller, which Sentry reports with the error
+func (e *ServiceError) WithStack() *ServiceError {
+	pcs := make([]uintptr, maxStackDepth)
+	// skip runtime.Callers and WithStack
+	n := runtime.Callers(2, pcs)
+	e.stack = pcs[:n]
+	return e
+}
+
+// Unwrap returns the cause of the error, if any
+func (e *ServiceError) Unwrap() error {
+	return e.cause
+}
+
+// StackTrace returns the program counters of the stack captured by WithStack, the method Sentry looks for
+func (e *ServiceError) StackTrace() []uintptr {
+	return e.stack
+}
+
+// Error describes the error and the chain of its causes, for the logs
 func (e *ServiceError) Error() string {
+	if e.cause != nil {
+		return fmt.Sprintf("%s: %s: %s", *CodeStr(e.Code), e.Reason, e.cause)
+	}
 	return fmt.Sprintf("%s: %s", *CodeStr(e.Code), e.Reason)
 }
 
+// AsError returns the error as an error, keeping its code and cause for errors.As and errors.Is
 func (e *ServiceError) AsError() error {
-	return fmt.Errorf("%s", e.Error())
+	return e
 }
 
 func (e *ServiceError) Is404() bool {
@@ -141,6 +229,10 @@ func (e *ServiceError) IsForbidden() bool {
 }
 
 func (e *ServiceError) AsOpenapiError(operationID string) openapi.Error {
+	var details []openapi.FieldViolation
+	for _, detail := range e.Details {
+		details = append(details, *openapi.NewFieldViolation(detail.Field, detail.Rule, detail.Message))
+	}
 	return openapi.Error{
 		Kind:        openapi.PtrString("Error"),
 		Id:          openapi.PtrString(strconv.Itoa(int(e.Code))),
@@ -148,6 +240,7 @@ func (e *ServiceError) AsOpenapiError(operationID string) openapi.Error {
 		Code:        CodeStr(e.Code),
 		Reason:      openapi.PtrString(e.Reason),
 		OperationId: openapi.PtrString(operationID),
+		Details:     details,
 	}
 }
 
@@ -204,6 +297,11 @@ func FailedToParseSearch(reason string, values ...interface{}) *ServiceError {
 	return New(ErrorFailedToParseSearch, message, values...)
 }
 
+// DatabaseAdvisoryLock keeps the database error as the cause, the reason sent to the client is the generic one
 func DatabaseAdvisoryLock(err error) *ServiceError {
-	return New(ErrorDatabaseAdvisoryLock, err.Error(), []string{})
+	return New(ErrorDatabaseAdvisoryLock, "").WithCause(err)
+}
+
+func IdempotencyKeyReused(reason string, values ...interface{}) *ServiceError {
+	return New(ErrorIdempotencyKeyReused, reason, values...)
 }
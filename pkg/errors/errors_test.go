@@ -1,6 +1,10 @@
 package errors
 
 import (
+	goerrors "errors"
+	"fmt"
+	"net/http"
+	"runtime"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -23,3 +27,98 @@ func TestErrorFind(t *testing.T) {
 	Expect(exists).To(Equal(false))
 	Expect(err).To(BeNil())
 }
+
+func TestErrorDetails(t *testing.T) {
+	RegisterTestingT(t)
+	err := Validation("species is required")
+	Expect(err.AsOpenapiError("").Details).To(BeNil())
+
+	err = err.WithDetails(FieldViolation{Field: "species", Rule: "required", Message: "species is required"})
+	Expect(err.Error()).To(Equal("rh-trex-8: species is required"))
+	details := err.AsOpenapiError("").Details
+	Expect(details).To(HaveLen(1))
+	Expect(details[0].GetField()).To(Equal("species"))
+	Expect(details[0].GetRule()).To(Equal("required"))
+	Expect(details[0].GetMessage()).To(Equal("species is required"))
+
+	// the errors found by code are copies
+	_, found := Find(ErrorValidation)
+	Expect(found.Details).To(BeNil())
+}
+
+func TestRegister(t *testing.T) {
+	RegisterTestingT(t)
+	t.Cleanup(func() { registered = nil })
+
+	quotaExceeded := ServiceErrorCode(1001)
+	Register(ServiceError{Code: quotaExceeded, Reason: "Quota exceeded", HttpCode: http.StatusTooManyRequests})
+
+	exists, err := Find(quotaExceeded)
+	Expect(exists).To(BeTrue())
+	Expect(err.HttpCode).To(Equal(http.StatusTooManyRequests))
+	Expect(Errors()[len(Errors())-1].Code).To(Equal(quotaExceeded))
+
+	err = New(quotaExceeded, "%d clusters at most", 3)
+	Expect(err.Reason).To(Equal("3 clusters at most"))
+	Expect(*err.AsOpenapiError("").Code).To(Equal("rh-trex-1001"))
+
+	// the codes are checked when the plugins register them, at startup
+	Expect(func() {
+		Register(ServiceError{Code: quotaExceeded, Reason: "Too many clusters", HttpCode: http.StatusTooManyRequests})
+	}).To(PanicWith("error code 1001 of 'Too many clusters' is already used by 'Quota exceeded'"))
+	Expect(func() {
+		Register(ServiceError{Code: ErrorNotFound, Reason: "Cluster not found", HttpCode: http.StatusNotFound})
+	}).To(PanicWith("error code 7 of 'Cluster not found' is kept for the built-in errors, the codes start at 1000"))
+	Expect(func() {
+		Register(ServiceError{Code: 1002, Reason: "Cluster ready", HttpCode: http.StatusOK})
+	}).To(PanicWith("error code 1002 of 'Cluster ready' has HTTP code 200, which isn't an error one"))
+	Expect(Errors()[len(Errors())-1].Code).To(Equal(quotaExceeded))
+}
+
+func TestErrorCodesAreDistinct(t *testing.T) {
+	RegisterTestingT(t)
+	codes := map[ServiceErrorCode]string{}
+	for _, err := range Errors() {
+		Expect(codes).NotTo(HaveKey(err.Code), err.Reason)
+		Expect(err.Code).To(BeNumerically("<", MinRegisteredCode), err.Reason)
+		codes[err.Code] = err.Reason
+	}
+}
+
+func TestErrorCause(t *testing.T) {
+	RegisterTestingT(t)
+
+	cause := fmt.Errorf("connection refused")
+	err := GeneralError("Unable to create Dinosaur").WithCause(cause)
+	Expect(err.Error()).To(Equal("rh-trex-9: Unable to create Dinosaur: connection refused"))
+	Expect(goerrors.Is(err, cause)).To(BeTrue())
+	Expect(goerrors.Is(err.AsError(), cause)).To(BeTrue())
+	var serviceErr *ServiceError
+	Expect(goerrors.As(fmt.Errorf("transaction: %w", err.AsError()), &serviceErr)).To(BeTrue())
+	Expect(serviceErr.Code).To(Equal(ErrorGeneral))
+
+	// the responses hold the reason alone
+	openapiErr := err.AsOpenapiError("")
+	Expect(openapiErr.GetReason()).To(Equal("Unable to create Dinosaur"))
+	problem := err.AsProblem("", "")
+	Expect(problem.GetDetail()).To(Equal("Unable to create Dinosaur"))
+
+	Expect(err.StackTrace()).To(BeEmpty())
+	err = err.WithStack()
+	frames := runtime.CallersFrames(err.StackTrace())
+	frame, _ := frames.Next()
+	Expect(frame.Function).To(HaveSuffix("errors.TestErrorCause"))
+}
+
+func TestDatabaseAdvisoryLock(t *testing.T) {
+	RegisterTestingT(t)
+
+	cause := fmt.Errorf("pq: canceling statement due to lock timeout")
+	err := DatabaseAdvisoryLock(cause)
+	Expect(err.Code).To(Equal(ErrorDatabaseAdvisoryLock))
+	Expect(err.HttpCode).To(Equal(http.StatusInternalServerError))
+	// the driver message is the cause, it isn't sent to the clients
+	Expect(err.Reason).To(Equal("Database advisory lock error"))
+	Expect(err.Reason).NotTo(ContainSubstring("lock timeout"))
+	Expect(goerrors.Is(err, cause)).To(BeTrue())
+}
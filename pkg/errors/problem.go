@@ -0,0 +1,111 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+)
+
+// ProblemContentType is the media type of the RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+type problemKey string
+
+// problemInstanceKey holds the path of the requests preferring problem details
+const problemInstanceKey problemKey = "problemInstance"
+
+// AsProblem returns the RFC 7807 problem details of the error, the type is the href of its code and the title
+// the generic reason of its code; the code, operation id and field violations of the openapi.Error are
+// extension members
+func (e *ServiceError) AsProblem(operationID string, instance string) openapi.Problem {
+	title := e.Reason
+	if exists, generic := Find(e.Code); exists {
+		title = generic.Reason
+	}
+	openapiErr := e.AsOpenapiError(operationID)
+	problem := openapi.Problem{
+		Type:    *Href(e.Code),
+		Title:   title,
+		Status:  int32(e.HttpCode),
+		Code:    *CodeStr(e.Code),
+		Details: openapiErr.Details,
+	}
+	if e.Reason != "" {
+		problem.Detail = &e.Reason
+	}
+	if instance != "" {
+		problem.Instance = &instance
+	}
+	if operationID != "" {
+		problem.OperationId = &operationID
+	}
+	return problem
+}
+
+// WriteResponse sends the error with its HTTP code, as problem details when the request prefers them,
+// see ProblemNegotiationMiddleware, and as an openapi.Error otherwise
+func (e *ServiceError) WriteResponse(ctx context.Context, w http.ResponseWriter, operationID string) {
+	var payload interface{}
+	if instance, ok := ctx.Value(problemInstanceKey).(string); ok {
+		w.Header().Set("Content-Type", ProblemContentType)
+		payload = e.AsProblem(operationID, instance)
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+		payload = e.AsOpenapiError(operationID)
+	}
+	w.WriteHeader(e.HttpCode)
+	response, _ := json.Marshal(payload)
+	_, _ = w.Write(response)
+}
+
+// ProblemNegotiationMiddleware records in the context of the requests whose Accept header prefers
+// application/problem+json to application/json that their errors are sent as problem details
+func ProblemNegotiationMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if PrefersProblem(r.Header.Get("Accept")) {
+			r = r.WithContext(context.WithValue(r.Context(), problemInstanceKey, r.URL.Path))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// PrefersProblem returns true when the Accept header gives application/problem+json a higher quality
+// than application/json, which is the default on a tie
+func PrefersProblem(accept string) bool {
+	// -1 stands for a media range absent from the header
+	problem, jsonExact, applicationRange, anyRange := 0.0, -1.0, -1.0, 0.0
+	for _, item := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(item, ";")
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(name, "q") {
+				if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		switch strings.ToLower(strings.TrimSpace(mediaType)) {
+		case ProblemContentType:
+			problem = max(problem, quality)
+		case "application/json":
+			jsonExact = max(jsonExact, quality)
+		case "application/*":
+			applicationRange = max(applicationRange, quality)
+		case "*/*":
+			anyRange = max(anyRange, quality)
+		}
+	}
+	// the most specific media range gives the quality of application/json
+	jsonQuality := anyRange
+	if jsonExact >= 0 {
+		jsonQuality = jsonExact
+	} else if applicationRange >= 0 {
+		jsonQuality = applicationRange
+	}
+	return problem > jsonQuality
+}
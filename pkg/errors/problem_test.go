@@ -0,0 +1,67 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestPrefersProblem(t *testing.T) {
+	RegisterTestingT(t)
+
+	tests := map[string]bool{
+		"":                         false,
+		"*/*":                      false,
+		"application/json":         false,
+		"application/problem+json": true,
+		"application/json, application/problem+json":               false,
+		"application/problem+json, application/json;q=0.9":         true,
+		"application/json;q=0.5, application/problem+json":         true,
+		"application/problem+json;q=0.5, */*":                      false,
+		"application/problem+json;q=0.5, text/html":                true,
+		"Application/Problem+JSON; charset=utf-8":                  true,
+		"application/*;q=0.2, application/problem+json;q=0.5, */*": true,
+	}
+	for accept, expected := range tests {
+		Expect(PrefersProblem(accept)).To(Equal(expected), accept)
+	}
+}
+
+func TestWriteResponse(t *testing.T) {
+	RegisterTestingT(t)
+
+	err := Validation("species is required").WithDetails(FieldViolation{Field: "species", Rule: "required", Message: "species is required"})
+	handler := ProblemNegotiationMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err.WriteResponse(r.Context(), w, "an-operation")
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/api/rh-trex/v1/dinosaurs", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	Expect(w.Code).To(Equal(http.StatusBadRequest))
+	Expect(w.Header().Get("Content-Type")).To(Equal("application/json"))
+	Expect(w.Body.String()).To(ContainSubstring(`"reason":"species is required"`))
+
+	r.Header.Set("Accept", ProblemContentType)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	Expect(w.Code).To(Equal(http.StatusBadRequest))
+	Expect(w.Header().Get("Content-Type")).To(Equal(ProblemContentType))
+	var problem map[string]interface{}
+	Expect(json.Unmarshal(w.Body.Bytes(), &problem)).To(Succeed())
+	Expect(problem).To(Equal(map[string]interface{}{
+		"type":         "/api/rh-trex/v1/errors/8",
+		"title":        "General validation failure",
+		"status":       float64(http.StatusBadRequest),
+		"detail":       "species is required",
+		"instance":     "/api/rh-trex/v1/dinosaurs",
+		"code":         "rh-trex-8",
+		"operation_id": "an-operation",
+		"details": []interface{}{
+			map[string]interface{}{"field": "species", "rule": "required", "message": "species is required"},
+		},
+	}))
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+)
+
+// MaxBulkOperations is the number of operations a bulk request can hold at most
+const MaxBulkOperations = 1000
+
+const (
+	BulkCreate = "create"
+	BulkPatch  = "patch"
+	BulkDelete = "delete"
+)
+
+// BulkConfig defines how the operations of a bulk request run for a kind.
+// Create, Patch and Delete return the HandlerConfig of the endpoint of the operation, so that every
+// operation is validated and runs the same way as a request of its own.
+//
+//	Transaction runs fn in a single database transaction, rolled back when fn fails
+//	Authorize, when set, checks the action of each operation before it runs: create, update for the patches, or delete
+type BulkConfig struct {
+	Create      func(ctx context.Context) *HandlerConfig
+	Patch       func(ctx context.Context, id string) *HandlerConfig
+	Delete      func(ctx context.Context, id string) *HandlerConfig
+	Transaction func(ctx context.Context, fn func(ctx context.Context) *errors.ServiceError) *errors.ServiceError
+	Authorize   func(ctx context.Context, action string) *errors.ServiceError
+}
+
+// bulkActions are the authorization actions of the bulk operations
+var bulkActions = map[string]string{
+	BulkCreate: auth.ActionCreate,
+	BulkPatch:  auth.ActionUpdate,
+	BulkDelete: auth.ActionDelete,
+}
+
+// HandleBulk runs the operations of a bulk request in order, in a single transaction.
+// When one fails all of them are rolled back and its error is returned, unless partial=true is given,
+// then every operation runs in a transaction of its own and the result of each, success or error, is returned.
+func HandleBulk(w http.ResponseWriter, r *http.Request, cfg *BulkConfig) {
+	ctx := r.Context()
+
+	if _, err := isDryRun(r); err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	partial := false
+	if value := r.URL.Query().Get("partial"); value != "" {
+		var err error
+		partial, err = strconv.ParseBool(value)
+		if err != nil {
+			HandleError(ctx, w, errors.Validation("partial must be true or false, not '%s'", value))
+			return
+		}
+	}
+
+	bytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		HandleError(ctx, w, errors.MalformedRequest("Unable to read request body: %s", err))
+		return
+	}
+
+	var request openapi.BulkRequest
+	err = json.Unmarshal(bytes, &request)
+	if err != nil {
+		HandleError(ctx, w, errors.MalformedRequest("Invalid request format: %s", err))
+		return
+	}
+	if len(request.Operations) == 0 {
+		HandleError(ctx, w, errors.Validation("operations cannot be empty"))
+		return
+	}
+	if len(request.Operations) > MaxBulkOperations {
+		HandleError(ctx, w, errors.Validation("operations cannot hold more than %d operations", MaxBulkOperations))
+		return
+	}
+
+	resultList := openapi.BulkResultList{
+		Kind:  "BulkResultList",
+		Items: []openapi.BulkResult{},
+	}
+
+	if partial {
+		operationID := logger.GetOperationID(ctx)
+		for _, operation := range request.Operations {
+			var result openapi.BulkResult
+			serviceErr := cfg.Transaction(ctx, func(ctx context.Context) *errors.ServiceError {
+				var err *errors.ServiceError
+				result, err = runBulkOperation(ctx, cfg, operation)
+				return err
+			})
+			if serviceErr != nil {
+				openapiErr := serviceErr.AsOpenapiError(operationID)
+				result = openapi.BulkResult{
+					Op:     operation.Op,
+					Id:     operation.Id,
+					Status: int32(serviceErr.HttpCode),
+					Error:  &openapiErr,
+				}
+			}
+			resultList.Items = append(resultList.Items, result)
+		}
+		writeJSONResponse(w, http.StatusOK, resultList)
+		return
+	}
+
+	serviceErr := cfg.Transaction(ctx, func(ctx context.Context) *errors.ServiceError {
+		for i, operation := range request.Operations {
+			result, err := runBulkOperation(ctx, cfg, operation)
+			if err != nil {
+				err.Reason = fmt.Sprintf("Operation %d failed, no operation was committed: %s", i, err.Reason)
+				for j := range err.Details {
+					err.Details[j].Field = fmt.Sprintf("operations[%d].item.%s", i, err.Details[j].Field)
+				}
+				return err
+			}
+			resultList.Items = append(resultList.Items, result)
+		}
+		return nil
+	})
+	if serviceErr != nil {
+		HandleError(ctx, w, serviceErr)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, resultList)
+}
+
+// runBulkOperation validates and runs the operation with the HandlerConfig of its endpoint
+func runBulkOperation(ctx context.Context, cfg *BulkConfig, operation openapi.BulkOperation) (openapi.BulkResult, *errors.ServiceError) {
+	result := openapi.BulkResult{Op: operation.Op, Id: operation.Id}
+
+	var opCfg *HandlerConfig
+	switch operation.Op {
+	case BulkCreate:
+		if operation.Id != nil {
+			return result, errors.Validation("id must be empty when creating")
+		}
+		opCfg = cfg.Create(ctx)
+		result.Status = http.StatusCreated
+	case BulkPatch, BulkDelete:
+		if operation.Id == nil || *operation.Id == "" {
+			return result, errors.Validation("id cannot be empty when the op is %s", operation.Op)
+		}
+		if operation.Op == BulkPatch {
+			opCfg = cfg.Patch(ctx, *operation.Id)
+			result.Status = http.StatusOK
+		} else {
+			opCfg = cfg.Delete(ctx, *operation.Id)
+			result.Status = http.StatusNoContent
+		}
+	default:
+		return result, errors.Validation("op must be one of %s, %s or %s, not '%s'", BulkCreate, BulkPatch, BulkDelete, operation.Op)
+	}
+
+	if cfg.Authorize != nil {
+		if err := cfg.Authorize(ctx, bulkActions[operation.Op]); err != nil {
+			return result, err
+		}
+	}
+
+	if opCfg.Body != nil {
+		item, err := json.Marshal(operation.GetItem())
+		if err != nil {
+			return result, errors.MalformedRequest("Invalid item format: %s", err)
+		}
+		// the item is decoded the same way as the request body of the endpoint, the patch items are merge patches
+		if serviceErr := decodeBody(opCfg, MergePatchContentType, item); serviceErr != nil {
+			return result, serviceErr
+		}
+	}
+
+	// a patch is validated by Patch.Apply, once the action has patched the resource
+	if opCfg.Patch == nil {
+		if err := validate(opCfg); err != nil {
+			return result, err
+		}
+	}
+
+	presented, serviceErr := opCfg.Action()
+	if serviceErr != nil {
+		return result, serviceErr
+	}
+	if presented != nil {
+		item, err := json.Marshal(presented)
+		if err != nil {
+			return result, errors.GeneralError("Unable to present the %s result: %s", operation.Op, err)
+		}
+		if err := json.Unmarshal(item, &result.Item); err != nil {
+			return result, errors.GeneralError("Unable to present the %s result: %s", operation.Op, err)
+		}
+		if id, ok := result.Item["id"].(string); ok && result.Id == nil {
+			result.Id = &id
+		}
+	}
+	return result, nil
+}
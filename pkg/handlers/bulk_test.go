@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+// fakeBulkStore keeps names by id, a transaction restores them when it fails
+type fakeBulkStore struct {
+	names map[string]string
+}
+
+func (s *fakeBulkStore) config() *BulkConfig {
+	return &BulkConfig{
+		Create: func(ctx context.Context) *HandlerConfig {
+			var item openapi.ObjectReference
+			return &HandlerConfig{
+				Body:       &item,
+				Validators: []Validate{ValidateNotEmpty(&item, "Kind", "kind")},
+				Action: func() (interface{}, *errors.ServiceError) {
+					id := *item.Kind + "-id"
+					if _, ok := s.names[id]; ok {
+						return nil, errors.Conflict("%s already exists", id)
+					}
+					s.names[id] = *item.Kind
+					return openapi.ObjectReference{Id: &id, Kind: item.Kind}, nil
+				},
+			}
+		},
+		Patch: func(ctx context.Context, id string) *HandlerConfig {
+			var item openapi.ObjectReference
+			return &HandlerConfig{
+				Body: &item,
+				Action: func() (interface{}, *errors.ServiceError) {
+					if _, ok := s.names[id]; !ok {
+						return nil, errors.NotFound("%s not found", id)
+					}
+					s.names[id] = *item.Kind
+					return openapi.ObjectReference{Id: &id, Kind: item.Kind}, nil
+				},
+			}
+		},
+		Delete: func(ctx context.Context, id string) *HandlerConfig {
+			return &HandlerConfig{
+				Action: func() (interface{}, *errors.ServiceError) {
+					if _, ok := s.names[id]; !ok {
+						return nil, errors.NotFound("%s not found", id)
+					}
+					delete(s.names, id)
+					return nil, nil
+				},
+			}
+		},
+		Transaction: func(ctx context.Context, fn func(ctx context.Context) *errors.ServiceError) *errors.ServiceError {
+			saved := map[string]string{}
+			for id, name := range s.names {
+				saved[id] = name
+			}
+			err := fn(ctx)
+			if err != nil {
+				s.names = saved
+			}
+			return err
+		},
+	}
+}
+
+func bulkRequest(store *fakeBulkStore, query string, body string) (int, []byte) {
+	r := httptest.NewRequest(http.MethodPost, "/bulk"+query, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	HandleBulk(w, r, store.config())
+	return w.Code, w.Body.Bytes()
+}
+
+func TestHandleBulk(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := &fakeBulkStore{names: map[string]string{"a-id": "a"}}
+	code, body := bulkRequest(store, "", `{"operations": [
+		{"op": "create", "item": {"kind": "b"}},
+		{"op": "patch", "id": "a-id", "item": {"kind": "c"}},
+		{"op": "delete", "id": "b-id"}
+	]}`)
+	Expect(code).To(Equal(http.StatusOK))
+
+	var results openapi.BulkResultList
+	Expect(json.Unmarshal(body, &results)).To(Succeed())
+	Expect(results.Kind).To(Equal("BulkResultList"))
+	Expect(results.Items).To(HaveLen(3))
+	Expect(results.Items[0].Status).To(Equal(int32(http.StatusCreated)))
+	Expect(results.Items[0].GetId()).To(Equal("b-id"))
+	Expect(results.Items[0].Item).To(HaveKeyWithValue("kind", "b"))
+	Expect(results.Items[1].Status).To(Equal(int32(http.StatusOK)))
+	Expect(results.Items[2].Status).To(Equal(int32(http.StatusNoContent)))
+	Expect(results.Items[2].Item).To(BeNil())
+	Expect(store.names).To(Equal(map[string]string{"a-id": "c"}))
+}
+
+func TestHandleBulkRollback(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := &fakeBulkStore{names: map[string]string{"a-id": "a"}}
+	code, body := bulkRequest(store, "", `{"operations": [
+		{"op": "create", "item": {"kind": "b"}},
+		{"op": "delete", "id": "missing"}
+	]}`)
+	Expect(code).To(Equal(http.StatusNotFound))
+
+	var openapiErr openapi.Error
+	Expect(json.Unmarshal(body, &openapiErr)).To(Succeed())
+	Expect(openapiErr.GetReason()).To(Equal("Operation 1 failed, no operation was committed: missing not found"))
+	Expect(store.names).To(Equal(map[string]string{"a-id": "a"}))
+
+	// the validators of the endpoint run for every operation, the fields they report are in the request
+	code, body = bulkRequest(store, "", `{"operations": [{"op": "create", "item": {"href": "b"}}]}`)
+	Expect(code).To(Equal(http.StatusBadRequest))
+	Expect(store.names).To(Equal(map[string]string{"a-id": "a"}))
+	Expect(json.Unmarshal(body, &openapiErr)).To(Succeed())
+	Expect(openapiErr.GetDetails()).To(Equal([]openapi.FieldViolation{
+		*openapi.NewFieldViolation("operations[0].item.kind", "required", "kind is required"),
+	}))
+}
+
+func TestHandleBulkPartial(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := &fakeBulkStore{names: map[string]string{"a-id": "a"}}
+	code, body := bulkRequest(store, "?partial=true", `{"operations": [
+		{"op": "create", "item": {"kind": "a"}},
+		{"op": "create", "item": {"kind": "b"}},
+		{"op": "patch", "item": {"kind": "c"}}
+	]}`)
+	Expect(code).To(Equal(http.StatusOK))
+
+	var results openapi.BulkResultList
+	Expect(json.Unmarshal(body, &results)).To(Succeed())
+	Expect(results.Items).To(HaveLen(3))
+	Expect(results.Items[0].Status).To(Equal(int32(http.StatusConflict)))
+	Expect(results.Items[0].Error.GetReason()).To(Equal("a-id already exists"))
+	Expect(results.Items[1].Status).To(Equal(int32(http.StatusCreated)))
+	Expect(results.Items[1].Error).To(BeNil())
+	Expect(results.Items[2].Status).To(Equal(int32(http.StatusBadRequest)))
+	Expect(results.Items[2].Error.GetReason()).To(Equal("id cannot be empty when the op is patch"))
+	Expect(store.names).To(Equal(map[string]string{"a-id": "a", "b-id": "b"}))
+}
+
+func TestHandleBulkInvalidRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := &fakeBulkStore{names: map[string]string{}}
+	tests := []struct {
+		query string
+		body  string
+		code  int
+	}{
+		{query: "?partial=maybe", body: `{"operations": [{"op": "create"}]}`, code: http.StatusBadRequest},
+		{body: `{"operations": []}`, code: http.StatusBadRequest},
+		{body: `{"items": []}`, code: http.StatusBadRequest},
+		{body: `{"operations": [{"op": "replace", "id": "a-id"}]}`, code: http.StatusBadRequest},
+		{body: `{"operations": [{"op": "create", "id": "a-id", "item": {"kind": "a"}}]}`, code: http.StatusBadRequest},
+		{body: `{"operations": [{"op": "create", "item": {"kind": 1}}]}`, code: http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		code, body := bulkRequest(store, test.query, test.body)
+		Expect(code).To(Equal(test.code), "%s %s: %s", test.query, test.body, body)
+	}
+	Expect(store.names).To(BeEmpty())
+}
+
+func TestHandleBulkAuthorize(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := &fakeBulkStore{names: map[string]string{"a-id": "a"}}
+	cfg := store.config()
+	var actions []string
+	cfg.Authorize = func(ctx context.Context, action string) *errors.ServiceError {
+		actions = append(actions, action)
+		if action != auth.ActionCreate {
+			return errors.Unauthorized("Account 'alice' is not allowed to %s Dinosaur", action)
+		}
+		return nil
+	}
+	r := httptest.NewRequest(http.MethodPost, "/bulk?partial=true", strings.NewReader(`{"operations": [
+		{"op": "create", "item": {"kind": "b"}},
+		{"op": "patch", "id": "a-id", "item": {"kind": "c"}},
+		{"op": "delete", "id": "a-id"}
+	]}`))
+	w := httptest.NewRecorder()
+	HandleBulk(w, r, cfg)
+	Expect(w.Code).To(Equal(http.StatusOK))
+
+	// each operation is authorized once, for its own action
+	Expect(actions).To(Equal([]string{auth.ActionCreate, auth.ActionUpdate, auth.ActionDelete}))
+	var results openapi.BulkResultList
+	Expect(json.Unmarshal(w.Body.Bytes(), &results)).To(Succeed())
+	Expect(results.Items[0].Status).To(Equal(int32(http.StatusCreated)))
+	Expect(results.Items[1].Status).To(Equal(int32(http.StatusForbidden)))
+	Expect(results.Items[2].Status).To(Equal(int32(http.StatusForbidden)))
+	Expect(store.names).To(Equal(map[string]string{"a-id": "a", "b-id": "b"}))
+}
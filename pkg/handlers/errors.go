@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex-ai/pkg/api/presenters"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+// errorHandler serves the catalog of the errors, the href of the error responses points at their entry
+type errorHandler struct{}
+
+func NewErrorsHandler() *errorHandler {
+	return &errorHandler{}
+}
+
+// List sends a page of the errors, the built-in ones and the ones registered by the plugins
+func (h errorHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := services.NewListArguments(r.URL.Query())
+			page, total := determineListRange(errors.Errors(), listArgs.Page, listArgs.Size)
+			errorList := openapi.ErrorList{
+				Kind:  "ErrorList",
+				Page:  int32(listArgs.Page),
+				Size:  int32(len(page)),
+				Total: int32(total),
+				Items: []openapi.Error{},
+			}
+			for _, item := range page {
+				serviceErr := item.(errors.ServiceError)
+				errorList.Items = append(errorList.Items, presenters.PresentError(&serviceErr))
+			}
+			return errorList, nil
+		},
+	}
+
+	HandleList(w, r, cfg)
+}
+
+// Get sends the error of a code
+func (h errorHandler) Get(w http.ResponseWriter, r *http.Request) {
+	cfg := &HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			code, err := strconv.Atoi(id)
+			if err != nil {
+				return nil, errors.NotFound("No error with id %s exists", id)
+			}
+			exists, serviceErr := errors.Find(errors.ServiceErrorCode(code))
+			if !exists {
+				return nil, errors.NotFound("No error with id %s exists", id)
+			}
+			return presenters.PresentError(serviceErr), nil
+		},
+	}
+
+	HandleGet(w, r, cfg)
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+func TestErrorsHandler(t *testing.T) {
+	RegisterTestingT(t)
+
+	handler := NewErrorsHandler()
+	router := mux.NewRouter()
+	router.HandleFunc("/errors", handler.List).Methods(http.MethodGet)
+	router.HandleFunc("/errors/{id}", handler.Get).Methods(http.MethodGet)
+	get := func(url string) (int, []byte) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+		return w.Code, w.Body.Bytes()
+	}
+
+	code, body := get("/errors")
+	Expect(code).To(Equal(http.StatusOK))
+	var list openapi.ErrorList
+	Expect(json.Unmarshal(body, &list)).To(Succeed())
+	Expect(list.Kind).To(Equal("ErrorList"))
+	Expect(list.Total).To(Equal(int32(len(errors.Errors()))))
+	Expect(list.Items).To(HaveLen(len(errors.Errors())))
+	Expect(list.Items[0].GetCode()).To(Equal("rh-trex-1"))
+	Expect(list.Items[0].HasOperationId()).To(BeFalse())
+
+	code, body = get("/errors?page=2&size=5")
+	Expect(code).To(Equal(http.StatusOK))
+	Expect(json.Unmarshal(body, &list)).To(Succeed())
+	Expect(list.Page).To(Equal(int32(2)))
+	Expect(list.Size).To(Equal(int32(5)))
+	Expect(list.Items[0].GetId()).To(Equal("9"))
+
+	code, body = get("/errors/8")
+	Expect(code).To(Equal(http.StatusOK))
+	var item openapi.Error
+	Expect(json.Unmarshal(body, &item)).To(Succeed())
+	Expect(item.GetKind()).To(Equal("Error"))
+	Expect(item.GetHref()).To(Equal("/api/rh-trex/v1/errors/8"))
+	Expect(item.GetCode()).To(Equal("rh-trex-8"))
+	Expect(item.GetReason()).To(Equal("General validation failure"))
+
+	for _, id := range []string{"91823719", "unknown"} {
+		code, _ = get("/errors/" + id)
+		Expect(code).To(Equal(http.StatusNotFound), id)
+	}
+}
@@ -5,9 +5,13 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
 
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	dbContext "github.com/openshift-online/rh-trex-ai/pkg/db/db_context"
 	"github.com/openshift-online/rh-trex-ai/pkg/errors"
 	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
 )
 
 // handlerConfig defines the common things each REST controller must do.
@@ -15,14 +19,25 @@ import (
 // This is not meant to be an HTTP framework or anything larger than simple CRUD in handlers.
 //
 //	MarshalInto is a pointer to the object to hold the unmarshaled JSON.
+//	Patch, when set, holds the merge or JSON patch of the request body instead of unmarshaling it into Body.
+//	  The action applies it with Patch.Apply, which unmarshals the patched object into Body and validates it.
 //	Validate is a list of validation function that run in order, returning fast on the first error.
 //	Action is the specific logic a handler must take (e.g, find an object, save an object)
 //	ErrorHandler is the way errors are returned to the client
+//	IdempotencyKeys, when set, stores the responses of the requests sent with an Idempotency-Key to replay them to the retries
 type HandlerConfig struct {
-	Body         interface{}
-	Validators   []Validate
-	Action       HTTPAction
-	ErrorHandler ErrorHandlerFunc
+	Body            interface{}
+	Patch           *Patch
+	Validators      []Validate
+	Action          HTTPAction
+	ErrorHandler    ErrorHandlerFunc
+	IdempotencyKeys services.IdempotencyKeyService
+}
+
+// Created is returned by the action of a PUT request which created the resource instead of replacing it,
+// the response is then 201 Created instead of the status given to Handle
+type Created struct {
+	Object interface{}
 }
 
 type Validate func() *errors.ServiceError
@@ -36,9 +51,9 @@ func HandleError(ctx context.Context, w http.ResponseWriter, err *errors.Service
 	if err.HttpCode >= 400 && err.HttpCode <= 499 {
 		log.Infof(err.Error())
 	} else {
-		log.Error(err.Error())
+		log.WithError(err).Error(err.Error())
 	}
-	writeJSONResponse(w, err.HttpCode, err.AsOpenapiError(operationID))
+	err.WriteResponse(ctx, w, operationID)
 }
 
 func Handle(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig, httpStatus int) {
@@ -46,41 +61,108 @@ func Handle(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig, httpStat
 		cfg.ErrorHandler = HandleError
 	}
 
-	bytes, err := io.ReadAll(r.Body)
-	if err != nil {
-		HandleError(r.Context(), w, errors.MalformedRequest("Unable to read request body: %s", err))
+	dryRun, serviceErr := isDryRun(r)
+	if serviceErr != nil {
+		cfg.ErrorHandler(r.Context(), w, serviceErr)
 		return
 	}
 
-	err = json.Unmarshal(bytes, &cfg.Body)
+	bytes, err := io.ReadAll(r.Body)
 	if err != nil {
-		HandleError(r.Context(), w, errors.MalformedRequest("Invalid request format: %s", err))
+		HandleError(r.Context(), w, errors.MalformedRequest("Unable to read request body: %s", err))
 		return
 	}
 
-	for _, v := range cfg.Validators {
-		err := v()
-		if err != nil {
-			cfg.ErrorHandler(r.Context(), w, err)
-			return
-		}
+	// the response of a dry run is neither stored nor replayed
+	if key := r.Header.Get(IdempotencyKeyHeader); key != "" && cfg.IdempotencyKeys != nil && !dryRun {
+		handleIdempotently(w, r, cfg, key, bytes, httpStatus)
+		return
 	}
 
-	result, serviceErr := cfg.Action()
+	result, serviceErr := runHandler(cfg, r.Header.Get("Content-Type"), bytes)
 
 	switch {
 	case serviceErr != nil:
 		cfg.ErrorHandler(r.Context(), w, serviceErr)
 	default:
-		writeJSONResponse(w, httpStatus, result)
+		writeJSONResponse(w, responseStatus(&result, httpStatus), result)
+	}
+
+}
+
+// responseStatus returns 201 Created for the Created results, which it unwraps, and httpStatus otherwise
+func responseStatus(result *interface{}, httpStatus int) int {
+	if created, ok := (*result).(Created); ok {
+		*result = created.Object
+		return http.StatusCreated
 	}
+	return httpStatus
+}
 
+// decodeBody unmarshals the request body into the config, or keeps it in the Patch of the config which has one
+func decodeBody(cfg *HandlerConfig, contentType string, bytes []byte) *errors.ServiceError {
+	if cfg.Patch != nil {
+		return cfg.Patch.set(cfg, contentType, bytes)
+	}
+	err := json.Unmarshal(bytes, &cfg.Body)
+	if err != nil {
+		return errors.MalformedRequest("Invalid request format: %s", err)
+	}
+	return nil
+}
+
+// isDryRun returns true if the request is sent with dryRun=true. The transaction middleware runs such requests
+// in a transaction which is always rolled back, they are refused when it did not so that nothing is persisted.
+func isDryRun(r *http.Request) (bool, *errors.ServiceError) {
+	value := r.URL.Query().Get(db.DryRunParam)
+	if value == "" {
+		return false, nil
+	}
+	dryRun, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errors.Validation("%s must be true or false, not '%s'", db.DryRunParam, value)
+	}
+	if dryRun && !dbContext.DryRun(r.Context()) {
+		return false, errors.GeneralError("Unable to run the request without persisting it")
+	}
+	return dryRun, nil
+}
+
+// runHandler decodes the request body into the config, validates it and runs the action
+func runHandler(cfg *HandlerConfig, contentType string, bytes []byte) (interface{}, *errors.ServiceError) {
+	if serviceErr := decodeBody(cfg, contentType, bytes); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	// a patched body is validated by Patch.Apply, once the action has patched the resource
+	if cfg.Patch == nil {
+		if serviceErr := validate(cfg); serviceErr != nil {
+			return nil, serviceErr
+		}
+	}
+
+	return cfg.Action()
+}
+
+// validate runs the validators of the config in order, returning the first error
+func validate(cfg *HandlerConfig) *errors.ServiceError {
+	for _, v := range cfg.Validators {
+		err := v()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func HandleDelete(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig, httpStatus int) {
 	if cfg.ErrorHandler == nil {
 		cfg.ErrorHandler = HandleError
 	}
+	if _, err := isDryRun(r); err != nil {
+		cfg.ErrorHandler(r.Context(), w, err)
+		return
+	}
 	for _, v := range cfg.Validators {
 		err := v()
 		if err != nil {
@@ -100,6 +182,12 @@ func HandleDelete(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig, ht
 
 }
 
+// HandleAction runs an action taking no request body, e.g. restoring a resource; such actions are
+// handled like the deletes
+func HandleAction(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig, httpStatus int) {
+	HandleDelete(w, r, cfg, httpStatus)
+}
+
 func HandleGet(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig) {
 	if cfg.ErrorHandler == nil {
 		cfg.ErrorHandler = HandleError
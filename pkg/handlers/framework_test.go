@@ -1,6 +1,22 @@
 package handlers
 
-import "net/http"
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+	daomocks "github.com/openshift-online/rh-trex-ai/pkg/dao/mocks"
+	dbContext "github.com/openshift-online/rh-trex-ai/pkg/db/db_context"
+	dbmocks "github.com/openshift-online/rh-trex-ai/pkg/db/mocks"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
 
 type mockResponseWriter struct {
 	written string
@@ -17,3 +33,125 @@ func (m *mockResponseWriter) Write(b []byte) (int, error) {
 func (m *mockResponseWriter) WriteHeader(code int) {
 	m.status = code
 }
+
+func TestHandleDryRun(t *testing.T) {
+	RegisterTestingT(t)
+
+	tests := []struct {
+		query  string
+		dryRun bool
+		code   int
+		ran    bool
+	}{
+		{query: "", code: http.StatusCreated, ran: true},
+		{query: "?dryRun=false", code: http.StatusCreated, ran: true},
+		{query: "?dryRun=true", dryRun: true, code: http.StatusCreated, ran: true},
+		{query: "?dryRun=maybe", dryRun: true, code: http.StatusBadRequest},
+		// the request is refused when it is not run in a dry run transaction
+		{query: "?dryRun=true", code: http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		ran := false
+		var item openapi.ObjectReference
+		cfg := &HandlerConfig{
+			Body: &item,
+			Action: func() (interface{}, *errors.ServiceError) {
+				ran = true
+				return item, nil
+			},
+		}
+
+		r := httptest.NewRequest(http.MethodPost, "/objects"+test.query, strings.NewReader(`{"kind": "a"}`))
+		if test.dryRun {
+			r = r.WithContext(dbContext.WithDryRun(r.Context()))
+		}
+		w := httptest.NewRecorder()
+		Handle(w, r, cfg, http.StatusCreated)
+		Expect(w.Code).To(Equal(test.code), "%s: %s", test.query, w.Body.String())
+		Expect(ran).To(Equal(test.ran), test.query)
+	}
+}
+
+func TestHandlePut(t *testing.T) {
+	RegisterTestingT(t)
+
+	names := map[string]string{"a-id": "a"}
+	put := func(id string, body string) (int, openapi.ObjectReference) {
+		var item openapi.ObjectReference
+		cfg := &HandlerConfig{
+			Body:       &item,
+			Validators: []Validate{ValidateIDMatches(&item, "Id", id)},
+			Action: func() (interface{}, *errors.ServiceError) {
+				_, found := names[id]
+				names[id] = *item.Kind
+				result := openapi.ObjectReference{Id: &id, Kind: item.Kind}
+				if !found {
+					return Created{Object: result}, nil
+				}
+				return result, nil
+			},
+		}
+		r := httptest.NewRequest(http.MethodPut, "/objects/"+id, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		Handle(w, r, cfg, http.StatusOK)
+
+		var result openapi.ObjectReference
+		Expect(json.Unmarshal(w.Body.Bytes(), &result)).To(Succeed())
+		return w.Code, result
+	}
+
+	code, result := put("a-id", `{"kind": "b"}`)
+	Expect(code).To(Equal(http.StatusOK))
+	Expect(result.GetKind()).To(Equal("b"))
+
+	code, result = put("c-id", `{"id": "c-id", "kind": "c"}`)
+	Expect(code).To(Equal(http.StatusCreated))
+	Expect(result.GetId()).To(Equal("c-id"))
+	Expect(result.GetKind()).To(Equal("c"))
+
+	code, _ = put("d-id", `{"id": "c-id", "kind": "d"}`)
+	Expect(code).To(Equal(http.StatusBadRequest))
+	Expect(names).To(Equal(map[string]string{"a-id": "b", "c-id": "c"}))
+}
+
+func TestHandleIdempotencyKey(t *testing.T) {
+	RegisterTestingT(t)
+
+	idempotencyKeys := services.NewIdempotencyKeyService(dbmocks.NewMockAdvisoryLockFactory(), daomocks.NewIdempotencyKeyDao(), time.Hour)
+	runs := 0
+	create := func(service services.IdempotencyKeyService, body string) (int, string) {
+		var item openapi.ObjectReference
+		cfg := &HandlerConfig{
+			Body: &item,
+			Action: func() (interface{}, *errors.ServiceError) {
+				runs++
+				return item, nil
+			},
+			IdempotencyKeys: service,
+		}
+		r := httptest.NewRequest(http.MethodPost, "/objects", strings.NewReader(body))
+		r.Header.Set(IdempotencyKeyHeader, "key")
+		w := httptest.NewRecorder()
+		Handle(w, r, cfg, http.StatusCreated)
+		return w.Code, w.Header().Get(IdempotentReplayedHeader)
+	}
+
+	code, replayed := create(idempotencyKeys, `{"kind": "a"}`)
+	Expect(code).To(Equal(http.StatusCreated))
+	Expect(replayed).To(BeEmpty())
+
+	code, replayed = create(idempotencyKeys, `{"kind": "a"}`)
+	Expect(code).To(Equal(http.StatusCreated))
+	Expect(replayed).To(Equal("true"))
+	Expect(runs).To(Equal(1))
+
+	code, _ = create(idempotencyKeys, `{"kind": "b"}`)
+	Expect(code).To(Equal(http.StatusUnprocessableEntity))
+	Expect(runs).To(Equal(1))
+
+	// the key is ignored by the handlers configured without the service
+	code, replayed = create(nil, `{"kind": "a"}`)
+	Expect(code).To(Equal(http.StatusCreated))
+	Expect(replayed).To(BeEmpty())
+	Expect(runs).To(Equal(2))
+}
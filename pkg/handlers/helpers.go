@@ -25,9 +25,14 @@ func determineListRange(obj interface{}, page int, size int64) (list []interface
 	items := reflect.ValueOf(obj)
 	total = int64(items.Len())
 	low := int64(page-1) * size
-	high := low + size
-	if low < 0 || low >= total || high >= total {
+	if low < 0 {
 		low = 0
+	}
+	high := low + size
+	if low > total {
+		low = total
+	}
+	if high > total {
 		high = total
 	}
 	for i := low; i < high; i++ {
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+const (
+	// IdempotencyKeyHeader is the header clients send a unique key in, to retry a request safely
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on the responses replayed for the retries of a request
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	// MaxIdempotencyKeyLength is the length of the longest key accepted
+	MaxIdempotencyKeyLength = 255
+)
+
+// handleIdempotently runs the request the first time it is sent with the key, and replays its response to the retries.
+// Reusing the key with a different body fails with 422 Unprocessable Entity.
+func handleIdempotently(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig, key string, bytes []byte, httpStatus int) {
+	ctx := r.Context()
+	if len(key) > MaxIdempotencyKeyLength {
+		cfg.ErrorHandler(ctx, w, errors.Validation("%s cannot be longer than %d characters", IdempotencyKeyHeader, MaxIdempotencyKeyLength))
+		return
+	}
+
+	hash := sha256.Sum256(bytes)
+	request := &api.IdempotencyKey{
+		Key:         key,
+		Username:    auth.GetUsernameFromContext(ctx),
+		Method:      r.Method,
+		Path:        r.URL.Path,
+		RequestHash: hex.EncodeToString(hash[:]),
+	}
+	response, replayed, serviceErr := cfg.IdempotencyKeys.Run(ctx, request, func() (int, []byte, *errors.ServiceError) {
+		result, serviceErr := runHandler(cfg, r.Header.Get("Content-Type"), bytes)
+		if serviceErr != nil {
+			return 0, nil, serviceErr
+		}
+		status := responseStatus(&result, httpStatus)
+		body, err := json.Marshal(result)
+		if err != nil {
+			return 0, nil, errors.GeneralError("Unable to marshal the response: %s", err)
+		}
+		return status, body, nil
+	})
+	if serviceErr != nil {
+		cfg.ErrorHandler(ctx, w, serviceErr)
+		return
+	}
+
+	if replayed {
+		w.Header().Set(IdempotentReplayedHeader, "true")
+	}
+	writeJSONResponse(w, response.StatusCode, json.RawMessage(response.Response))
+}
@@ -2,12 +2,17 @@ package handlers
 
 import (
 	"embed"
+	"encoding/json"
+	"fmt"
 	"io/fs"
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/ghodss/yaml"
 	"github.com/golang/glog"
 	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
 )
 
 //go:embed openapi-ui.html
@@ -26,6 +31,13 @@ func NewOpenAPIHandler(specData []byte) (*OpenAPIHandler, error) {
 			err,
 		)
 	}
+	data, err = publishFieldAllowlists(data, services.FieldAllowlists())
+	if err != nil {
+		return nil, errors.GeneralError(
+			"can't publish the field allowlists in the OpenAPI specification: %v",
+			err,
+		)
+	}
 	glog.Info("Loaded OpenAPI specification")
 
 	uiContent, err := fs.ReadFile(openapiui, "openapi-ui.html")
@@ -54,3 +66,71 @@ func (h *OpenAPIHandler) GetOpenAPIUI(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write(h.uiContent)
 }
+
+// publishFieldAllowlists documents the fields the kinds allow to search, sort and project on in
+// their list endpoints, the GET operations returning a <Kind>List. The fields are appended to the
+// description of the operation and listed in the x-searchable-fields, x-sortable-fields and
+// x-projectable-fields extensions.
+func publishFieldAllowlists(data []byte, allowlists map[string]services.FieldAllowlist) ([]byte, error) {
+	if len(allowlists) == 0 {
+		return data, nil
+	}
+
+	var spec map[string]interface{}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, err
+	}
+	paths, _ := spec["paths"].(map[string]interface{})
+	for _, item := range paths {
+		pathItem, _ := item.(map[string]interface{})
+		operation, _ := pathItem["get"].(map[string]interface{})
+		kind := listKind(operation)
+		allowlist, ok := allowlists[kind]
+		if !ok {
+			continue
+		}
+
+		operation["x-searchable-fields"] = sortedFields(allowlist.Searchable)
+		operation["x-sortable-fields"] = sortedFields(allowlist.Sortable)
+		operation["x-projectable-fields"] = sortedFields(allowlist.Projectable)
+		description := fmt.Sprintf("Searchable fields: %s.\n\nSortable fields: %s.\n\nProjectable fields: %s.",
+			describeFields(allowlist.Searchable), describeFields(allowlist.Sortable), describeFields(allowlist.Projectable))
+		if existing, ok := operation["description"].(string); ok && existing != "" {
+			description = existing + "\n\n" + description
+		}
+		operation["description"] = description
+	}
+	return json.Marshal(spec)
+}
+
+// listKind returns the kind an operation lists, e.g. Dinosaur when it returns a DinosaurList
+func listKind(operation map[string]interface{}) string {
+	responses, _ := operation["responses"].(map[string]interface{})
+	ok, _ := responses["200"].(map[string]interface{})
+	content, _ := ok["content"].(map[string]interface{})
+	media, _ := content["application/json"].(map[string]interface{})
+	schema, _ := media["schema"].(map[string]interface{})
+	ref, _ := schema["$ref"].(string)
+	name := strings.TrimPrefix(ref, "#/components/schemas/")
+	if name == ref || !strings.HasSuffix(name, "List") {
+		return ""
+	}
+	return strings.TrimSuffix(name, "List")
+}
+
+func sortedFields(fields []string) []string {
+	sorted := append([]string{}, fields...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func describeFields(fields []string) string {
+	if len(fields) == 0 {
+		return "none"
+	}
+	quoted := make([]string, 0, len(fields))
+	for _, field := range sortedFields(fields) {
+		quoted = append(quoted, fmt.Sprintf("`%s`", field))
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+func TestPublishFieldAllowlists(t *testing.T) {
+	RegisterTestingT(t)
+
+	specData, err := api.GetOpenAPISpec()
+	Expect(err).NotTo(HaveOccurred())
+	data, err := yaml.YAMLToJSON(specData)
+	Expect(err).NotTo(HaveOccurred())
+
+	data, err = publishFieldAllowlists(data, map[string]services.FieldAllowlist{
+		"Dinosaur": {
+			Searchable: []string{"species", "id"},
+			Sortable:   []string{"species"},
+		},
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	var spec struct {
+		Paths map[string]map[string]map[string]interface{} `json:"paths"`
+	}
+	Expect(json.Unmarshal(data, &spec)).To(Succeed())
+
+	list := spec.Paths["/api/rh-trex/v1/dinosaurs"]["get"]
+	Expect(list["x-searchable-fields"]).To(Equal([]interface{}{"id", "species"}))
+	Expect(list["x-sortable-fields"]).To(Equal([]interface{}{"species"}))
+	Expect(list["x-projectable-fields"]).To(BeEmpty())
+	Expect(list["description"]).To(Equal("Searchable fields: `id`, `species`.\n\nSortable fields: `species`.\n\nProjectable fields: none."))
+
+	// only the list endpoints document the allowlist
+	Expect(spec.Paths["/api/rh-trex/v1/dinosaurs/{id}"]["get"]).NotTo(HaveKey("x-searchable-fields"))
+	Expect(spec.Paths["/api/rh-trex/v1/dinosaurs/aggregate"]["get"]).NotTo(HaveKey("x-searchable-fields"))
+}
@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	goerrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/golang/glog"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+// NewRequestValidationMiddleware returns a middleware checking the path parameters, query parameters and bodies
+// of the requests against the operations of the OpenAPI specification, the requests which don't match are
+// rejected with a 400 holding a field violation for each issue found. The requests of the paths and methods the specification
+// doesn't describe are let through, the router answers them.
+func NewRequestValidationMiddleware(specData []byte) (func(http.Handler) http.Handler, error) {
+	router, err := newOpenAPIRouter(specData)
+	if err != nil {
+		return nil, err
+	}
+	options := &openapi3filter.Options{
+		MultiError: true,
+		// the authentication middleware checks the tokens
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		// the handlers apply the defaults of their parameters
+		SkipSettingDefaults: true,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			}
+			if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				HandleError(r.Context(), w, errors.Validation("The request doesn't match the API specification").
+					WithDetails(openAPIIssues(err)...))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+// ResponseViolations records the responses which don't match the OpenAPI specification
+type ResponseViolations struct {
+	lock       sync.Mutex
+	violations []string
+}
+
+func (v *ResponseViolations) add(violation string) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.violations = append(v.violations, violation)
+}
+
+// Take returns the violations recorded since the previous call
+func (v *ResponseViolations) Take() []string {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	violations := v.violations
+	v.violations = nil
+	return violations
+}
+
+// NewResponseValidationMiddleware returns a middleware checking the status, content type and body of the responses
+// against the operations of the OpenAPI specification, the responses which don't match are sent as they are and
+// recorded in the violations, for the tests to fail on them. It must be the last middleware, seeing the responses
+// before they are compressed.
+func NewResponseValidationMiddleware(specData []byte, violations *ResponseViolations) (func(http.Handler) http.Handler, error) {
+	router, err := newOpenAPIRouter(specData)
+	if err != nil {
+		return nil, err
+	}
+	options := &openapi3filter.Options{
+		MultiError:            true,
+		IncludeResponseStatus: true,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+
+			input := &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: &openapi3filter.RequestValidationInput{
+					Request:    r,
+					PathParams: pathParams,
+					Route:      route,
+					Options:    options,
+				},
+				Status:  recorder.status,
+				Header:  recorder.Header(),
+				Options: options,
+			}
+			input.SetBodyBytes(recorder.body.Bytes())
+			if err := openapi3filter.ValidateResponse(r.Context(), input); err != nil {
+				var messages []string
+				for _, issue := range openAPIIssues(err) {
+					messages = append(messages, issue.Message)
+				}
+				violation := fmt.Sprintf("%s %s responded %d: %s", r.Method, r.URL.Path, recorder.status,
+					strings.Join(messages, ", "))
+				glog.Errorf("The response doesn't match the API specification: %s", violation)
+				violations.add(violation)
+			}
+		})
+	}, nil
+}
+
+// responseRecorder keeps a copy of the response it writes
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+// newOpenAPIRouter finds the operations of the requests in the specification, whatever the host they are sent to
+func newOpenAPIRouter(specData []byte) (routers.Router, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(specData)
+	if err != nil {
+		return nil, errors.GeneralError("can't load the OpenAPI specification: %v", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, errors.GeneralError("invalid OpenAPI specification: %v", err)
+	}
+	doc.Servers = openapi3.Servers{{URL: "/"}}
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, errors.GeneralError("can't route the operations of the OpenAPI specification: %v", err)
+	}
+	return router, nil
+}
+
+// openAPIRule is the rule of the issues which aren't about a schema, like the parameters which can't be parsed
+const openAPIRule = "openapi"
+
+// openAPIIssues describes each issue of a validation error as a violation of the parameter or the field of the body
+// it is about, the field of the issues about the whole body being "body"
+func openAPIIssues(err error) []errors.FieldViolation {
+	var schemaErr *openapi3.SchemaError
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var issues []errors.FieldViolation
+		for _, err := range e {
+			issues = append(issues, openAPIIssues(err)...)
+		}
+		return issues
+	case *openapi3filter.RequestError:
+		reasons := []errors.FieldViolation{{Rule: openAPIRule, Message: e.Reason}}
+		if goerrors.As(e.Err, &schemaErr) {
+			reasons = schemaIssues(e.Err, nil)
+		} else if e.Err != nil && e.Reason == "" {
+			reasons = []errors.FieldViolation{{Rule: openAPIRule, Message: e.Err.Error()}}
+		}
+		var issues []errors.FieldViolation
+		for _, reason := range reasons {
+			switch {
+			case e.Parameter != nil:
+				reason.Field = e.Parameter.Name
+				reason.Message = fmt.Sprintf("%s parameter '%s': %s", e.Parameter.In, e.Parameter.Name, reason.Message)
+			case e.RequestBody != nil:
+				reason.Field = bodyField(reason.Field)
+				reason.Message = fmt.Sprintf("body: %s", reason.Message)
+			}
+			issues = append(issues, reason)
+		}
+		return issues
+	case *openapi3filter.ResponseError:
+		if !goerrors.As(e.Err, &schemaErr) {
+			return []errors.FieldViolation{{Rule: openAPIRule, Message: e.Error()}}
+		}
+		var issues []errors.FieldViolation
+		for _, reason := range schemaIssues(e.Err, nil) {
+			reason.Field = bodyField(reason.Field)
+			reason.Message = fmt.Sprintf("body: %s", reason.Message)
+			issues = append(issues, reason)
+		}
+		return issues
+	default:
+		return []errors.FieldViolation{{Rule: openAPIRule, Message: err.Error()}}
+	}
+}
+
+// bodyField returns the path of the field of the body, or "body" for the whole body
+func bodyField(path string) string {
+	if path == "" {
+		return "body"
+	}
+	return path
+}
+
+// schemaIssues describes each field of the errors of a schema, without the schema and the value their messages hold,
+// the rule of each violation being the keyword of the schema it failed, like `required` or `maxLength`
+func schemaIssues(err error, path []string) []errors.FieldViolation {
+	for e := err; e != nil; e = goerrors.Unwrap(e) {
+		switch e := e.(type) {
+		case openapi3.MultiError:
+			var issues []errors.FieldViolation
+			for _, err := range e {
+				issues = append(issues, schemaIssues(err, path)...)
+			}
+			return issues
+		case *openapi3.SchemaError:
+			fieldPath := append(append([]string{}, path...), e.JSONPointer()...)
+			if e.SchemaField == "allOf" && e.Origin != nil {
+				// the errors of the allOf schemas, like the ones of the kinds extending ObjectReference,
+				// hold the errors of all their parts, with paths relative to the allOf schema
+				return schemaIssues(e.Origin, fieldPath)
+			}
+			field := strings.Join(fieldPath, ".")
+			issue := errors.FieldViolation{Field: field, Rule: e.SchemaField, Message: e.Reason}
+			switch {
+			case e.SchemaField == "required":
+				// the path of a missing property ends with its name
+				issue.Message = fmt.Sprintf("%s is required", field)
+			case field != "":
+				issue.Message = fmt.Sprintf("%s %s", field, e.Reason)
+			}
+			return []errors.FieldViolation{issue}
+		}
+	}
+	return []errors.FieldViolation{{Rule: openAPIRule, Message: err.Error()}}
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+func TestRequestValidationMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	specData, err := api.GetOpenAPISpec()
+	Expect(err).NotTo(HaveOccurred())
+	middleware, err := NewRequestValidationMiddleware(specData)
+	Expect(err).NotTo(HaveOccurred())
+
+	served := false
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		method      string
+		url         string
+		contentType string
+		body        string
+		issues      []errors.FieldViolation
+	}{
+		{method: http.MethodGet, url: "/api/rh-trex/v1/dinosaurs?page=2&size=10"},
+		{method: http.MethodPost, url: "/api/rh-trex/v1/dinosaurs", contentType: "application/json", body: `{"species": "Diplodocus"}`},
+		{method: http.MethodPatch, url: "/api/rh-trex/v1/dinosaurs/a-id", contentType: MergePatchContentType, body: `{"species": "Diplodocus"}`},
+		{method: http.MethodPatch, url: "/api/rh-trex/v1/dinosaurs/a-id", contentType: JSONPatchContentType, body: `[{"op": "remove", "path": "/labels"}]`},
+		// the paths and methods the specification doesn't describe are let through
+		{method: http.MethodGet, url: "/api/rh-trex/v1/unknown"},
+		{method: http.MethodDelete, url: "/api/rh-trex/v1/dinosaurs"},
+		{
+			method: http.MethodGet,
+			url:    "/api/rh-trex/v1/dinosaurs?page=first",
+			issues: []errors.FieldViolation{
+				{Field: "page", Rule: "openapi", Message: "query parameter 'page': value first: an invalid integer: invalid syntax"},
+			},
+		},
+		{
+			method:      http.MethodPost,
+			url:         "/api/rh-trex/v1/dinosaurs",
+			contentType: "application/json",
+			body:        `{"species": 3, "labels": {"era": 1}}`,
+			issues: []errors.FieldViolation{
+				{Field: "labels.era", Rule: "type", Message: "body: labels.era value must be a string"},
+				{Field: "species", Rule: "type", Message: "body: species value must be a string"},
+			},
+		},
+		{
+			method:      http.MethodPost,
+			url:         "/api/rh-trex/v1/dinosaurs",
+			contentType: "application/json",
+			body:        `{"species": "` + strings.Repeat("a", 256) + `"}`,
+			issues: []errors.FieldViolation{
+				{Field: "species", Rule: "maxLength", Message: "body: species maximum string length is 255"},
+			},
+		},
+		{
+			method:      http.MethodPost,
+			url:         "/api/rh-trex/v1/dinosaurs",
+			contentType: "application/json",
+			body:        `{}`,
+			issues: []errors.FieldViolation{
+				{Field: "species", Rule: "required", Message: "body: species is required"},
+			},
+		},
+	}
+	for _, test := range tests {
+		served = false
+		r := httptest.NewRequest(test.method, test.url, strings.NewReader(test.body))
+		if test.contentType != "" {
+			r.Header.Set("Content-Type", test.contentType)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		if len(test.issues) == 0 {
+			Expect(served).To(BeTrue(), "%s %s: %s", test.method, test.url, w.Body.String())
+			continue
+		}
+		Expect(served).To(BeFalse(), "%s %s", test.method, test.url)
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+		var body openapi.Error
+		Expect(decodeJSON(w.Body.Bytes(), &body)).To(Succeed())
+		Expect(body.GetReason()).To(Equal("The request doesn't match the API specification"))
+		var issues []errors.FieldViolation
+		for _, detail := range body.GetDetails() {
+			issues = append(issues, errors.FieldViolation{Field: detail.Field, Rule: detail.Rule, Message: detail.Message})
+		}
+		Expect(issues).To(Equal(test.issues), "%s %s", test.method, test.url)
+	}
+}
+
+func TestResponseValidationMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	specData, err := api.GetOpenAPISpec()
+	Expect(err).NotTo(HaveOccurred())
+	violations := &ResponseViolations{}
+	middleware, err := NewResponseValidationMiddleware(specData, violations)
+	Expect(err).NotTo(HaveOccurred())
+
+	tests := []struct {
+		url         string
+		status      int
+		contentType string
+		body        string
+		violation   string
+	}{
+		{url: "/api/rh-trex/v1/dinosaurs", status: http.StatusOK, body: `{"kind":"DinosaurList","page":1,"size":1,"total":1,"items":[{"id":"a-id","species":"Diplodocus"}]}`},
+		{url: "/api/rh-trex/v1/dinosaurs/a-id", status: http.StatusNotFound, body: `{"kind":"Error","reason":"Not found"}`},
+		{url: "/api/rh-trex/v1/unknown", status: http.StatusTeapot, contentType: "text/plain", body: "short and stout"},
+		{
+			url:         "/api/rh-trex/v1/dinosaurs/a-id",
+			status:      http.StatusNotFound,
+			contentType: errors.ProblemContentType,
+			body:        `{"type":"/api/rh-trex/v1/errors/7","title":"Resource not found","status":404,"code":"rh-trex-7"}`,
+		},
+		{
+			url:         "/api/rh-trex/v1/dinosaurs/a-id",
+			status:      http.StatusNotFound,
+			contentType: errors.ProblemContentType,
+			body:        `{"type":"/api/rh-trex/v1/errors/7","status":404,"code":"rh-trex-7"}`,
+			violation:   "GET /api/rh-trex/v1/dinosaurs/a-id responded 404: body: title is required",
+		},
+		{
+			url:       "/api/rh-trex/v1/dinosaurs",
+			status:    http.StatusOK,
+			body:      `{"kind":"DinosaurList","page":1,"size":0,"total":0,"items":[{"id":"a-id"}]}`,
+			violation: "GET /api/rh-trex/v1/dinosaurs responded 200: body: items.0.species is required",
+		},
+		{
+			url:       "/api/rh-trex/v1/dinosaurs/a-id",
+			status:    http.StatusTeapot,
+			body:      `{"kind":"Error"}`,
+			violation: "GET /api/rh-trex/v1/dinosaurs/a-id responded 418: status is not supported",
+		},
+		{
+			url:         "/api/rh-trex/v1/dinosaurs/a-id",
+			status:      http.StatusOK,
+			contentType: "text/plain",
+			body:        "Diplodocus",
+			violation:   `GET /api/rh-trex/v1/dinosaurs/a-id responded 200: response header Content-Type has unexpected value: "text/plain"`,
+		},
+	}
+	for _, test := range tests {
+		handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contentType := test.contentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(test.status)
+			_, _ = w.Write([]byte(test.body))
+		}))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, test.url, nil))
+
+		// the response is sent as it is
+		Expect(w.Code).To(Equal(test.status))
+		Expect(w.Body.String()).To(Equal(test.body))
+		if test.violation == "" {
+			Expect(violations.Take()).To(BeEmpty(), test.body)
+			continue
+		}
+		Expect(violations.Take()).To(Equal([]string{test.violation}))
+	}
+	Expect(violations.Take()).To(BeEmpty())
+}
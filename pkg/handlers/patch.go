@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"reflect"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+const (
+	// MergePatchContentType is the content type of the RFC 7396 JSON Merge Patch documents,
+	// the patch requests sent as application/json are merge patches too
+	MergePatchContentType = "application/merge-patch+json"
+	// JSONPatchContentType is the content type of the RFC 6902 JSON Patch documents
+	JSONPatchContentType = "application/json-patch+json"
+)
+
+// readOnlyFields can't be changed by a patch, they are set by the server
+var readOnlyFields = []string{"id", "kind", "href", "created_at", "updated_at", "deleted_at"}
+
+// Patch is the merge or JSON patch document of a patch request. Handle keeps it for the action instead of
+// decoding it into the Body, and the action applies it with Apply onto the resource it read under the lock
+// of the update, so that concurrent patches to different fields of a resource don't overwrite each other.
+type Patch struct {
+	cfg      *HandlerConfig
+	apply    func(original, patch []byte) ([]byte, error)
+	document []byte
+}
+
+// set checks the patch document of the request, the content type tells which kind of patch it is
+func (p *Patch) set(cfg *HandlerConfig, contentType string, document []byte) *errors.ServiceError {
+	apply, serviceErr := patchFunc(contentType)
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	var decoded interface{}
+	if err := decodeJSON(document, &decoded); err != nil {
+		return errors.MalformedRequest("Invalid request format: %s", err)
+	}
+
+	p.cfg = cfg
+	p.apply = apply
+	p.document = document
+	return nil
+}
+
+// Apply applies the patch onto the presented resource, decodes the patched resource into the Body of the
+// config and runs the validators of the config on it
+func (p *Patch) Apply(presented interface{}) *errors.ServiceError {
+	if p.cfg == nil {
+		return errors.GeneralError("Unable to apply a patch which was not read from the request")
+	}
+	original, err := json.Marshal(presented)
+	if err != nil {
+		return errors.GeneralError("Unable to present the resource to patch: %s", err)
+	}
+
+	patched, err := p.apply(original, p.document)
+	if err != nil {
+		return errors.MalformedRequest("Unable to apply the patch: %s", err)
+	}
+
+	var originalObject, patchedObject map[string]interface{}
+	if err := decodeJSON(original, &originalObject); err != nil {
+		return errors.GeneralError("Unable to present the resource to patch: %s", err)
+	}
+	if err := decodeJSON(patched, &patchedObject); err != nil || patchedObject == nil {
+		return errors.Validation("The patched resource must be an object")
+	}
+	for _, field := range readOnlyFields {
+		if !reflect.DeepEqual(originalObject[field], patchedObject[field]) {
+			return errors.Validation("%s is read-only", field)
+		}
+	}
+
+	if err := json.Unmarshal(patched, &p.cfg.Body); err != nil {
+		return errors.MalformedRequest("Invalid request format: %s", err)
+	}
+	return validate(p.cfg)
+}
+
+// patchFunc returns the function applying the patch documents of the content type
+func patchFunc(contentType string) (func(original, patch []byte) ([]byte, error), *errors.ServiceError) {
+	if contentType == "" {
+		return jsonpatch.MergePatch, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid Content-Type '%s': %s", contentType, err)
+	}
+	switch mediaType {
+	case MergePatchContentType, "application/json":
+		return jsonpatch.MergePatch, nil
+	case JSONPatchContentType:
+		return applyJSONPatch, nil
+	default:
+		return nil, errors.BadRequest("Content-Type must be %s or %s, not '%s'", MergePatchContentType, JSONPatchContentType, mediaType)
+	}
+}
+
+// applyJSONPatch applies an RFC 6902 JSON Patch, a list of operations which either all apply or none does
+func applyJSONPatch(original, patch []byte) ([]byte, error) {
+	operations, err := jsonpatch.DecodePatch(patch)
+	if err != nil {
+		return nil, err
+	}
+	// the library ignores the operations on the whole document, which would replace the read-only fields
+	for i, operation := range operations {
+		if path, _ := operation.Path(); path == "" {
+			return nil, fmt.Errorf("operation %d: the whole resource can't be patched, the path must point to a field", i)
+		}
+	}
+	return operations.Apply(original)
+}
+
+// decodeJSON keeps the numbers as they are written, so that large integers are not rounded
+func decodeJSON(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return fmt.Errorf("unexpected data after the JSON value")
+	}
+	return nil
+}
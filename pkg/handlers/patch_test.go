@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+func TestMergePatch(t *testing.T) {
+	RegisterTestingT(t)
+
+	// the examples of RFC 7396
+	tests := []struct {
+		target string
+		patch  string
+		result string
+	}{
+		{target: `{"a":"b"}`, patch: `{"a":"c"}`, result: `{"a":"c"}`},
+		{target: `{"a":"b"}`, patch: `{"b":"c"}`, result: `{"a":"b","b":"c"}`},
+		{target: `{"a":"b"}`, patch: `{"a":null}`, result: `{}`},
+		{target: `{"a":"b","b":"c"}`, patch: `{"a":null}`, result: `{"b":"c"}`},
+		{target: `{"a":["b"]}`, patch: `{"a":"c"}`, result: `{"a":"c"}`},
+		{target: `{"a":"c"}`, patch: `{"a":["b"]}`, result: `{"a":["b"]}`},
+		{target: `{"a":{"b":"c"}}`, patch: `{"a":{"b":"d","c":null}}`, result: `{"a":{"b":"d"}}`},
+		{target: `{"a":[{"b":"c"}]}`, patch: `{"a":[1]}`, result: `{"a":[1]}`},
+		{target: `["a","b"]`, patch: `["c","d"]`, result: `["c","d"]`},
+		{target: `{"a":"b"}`, patch: `["c"]`, result: `["c"]`},
+		{target: `{"e":null}`, patch: `{"a":1}`, result: `{"a":1,"e":null}`},
+		{target: `[1,2]`, patch: `{"a":"b","c":null}`, result: `{"a":"b"}`},
+		{target: `{}`, patch: `{"a":{"bb":{"ccc":null}}}`, result: `{"a":{"bb":{}}}`},
+	}
+	for _, test := range tests {
+		result, err := jsonpatch.MergePatch([]byte(test.target), []byte(test.patch))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(MatchJSON(test.result), "%s + %s", test.target, test.patch)
+	}
+}
+
+func TestJSONPatch(t *testing.T) {
+	RegisterTestingT(t)
+
+	tests := []struct {
+		target string
+		patch  string
+		result string
+		err    bool
+	}{
+		{target: `{"foo":"bar"}`, patch: `[{"op":"add","path":"/baz","value":"qux"}]`, result: `{"baz":"qux","foo":"bar"}`},
+		{target: `{"foo":["bar","baz"]}`, patch: `[{"op":"add","path":"/foo/1","value":"qux"}]`, result: `{"foo":["bar","qux","baz"]}`},
+		{target: `{"foo":["bar"]}`, patch: `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, result: `{"foo":["bar",["abc","def"]]}`},
+		{target: `{"baz":"qux","foo":"bar"}`, patch: `[{"op":"remove","path":"/baz"}]`, result: `{"foo":"bar"}`},
+		{target: `{"foo":["bar","qux","baz"]}`, patch: `[{"op":"remove","path":"/foo/1"}]`, result: `{"foo":["bar","baz"]}`},
+		{target: `{"baz":"qux","foo":"bar"}`, patch: `[{"op":"replace","path":"/baz","value":"boo"}]`, result: `{"baz":"boo","foo":"bar"}`},
+		{target: `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, patch: `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, result: `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{target: `{"foo":["all","grass","cows","eat"]}`, patch: `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, result: `{"foo":["all","cows","eat","grass"]}`},
+		{target: `{"foo":{"bar":[1]}}`, patch: `[{"op":"copy","from":"/foo/bar","path":"/baz"},{"op":"add","path":"/baz/-","value":2}]`, result: `{"foo":{"bar":[1]},"baz":[1,2]}`},
+		{target: `{"baz":"qux","foo":["a",2,"c"]}`, patch: `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`, result: `{"baz":"qux","foo":["a",2,"c"]}`},
+		{target: `{"/":1,"~":2}`, patch: `[{"op":"replace","path":"/~1","value":3},{"op":"remove","path":"/~0"}]`, result: `{"/":3}`},
+		{target: `{"foo":"bar"}`, patch: `[{"op":"add","path":"","value":{"baz":1}}]`, err: true},
+		{target: `{"baz":"qux"}`, patch: `[{"op":"test","path":"/baz","value":"bar"}]`, err: true},
+		{target: `{"foo":"bar"}`, patch: `[{"op":"add","path":"/baz/bat","value":"qux"}]`, err: true},
+		{target: `{"foo":"bar"}`, patch: `[{"op":"remove","path":"/baz"}]`, err: true},
+		{target: `{"foo":["bar"]}`, patch: `[{"op":"add","path":"/foo/2","value":"qux"}]`, err: true},
+		{target: `{"foo":["bar"]}`, patch: `[{"op":"remove","path":"/foo/01"}]`, err: true},
+		{target: `{"foo":{"bar":1}}`, patch: `[{"op":"move","from":"/foo","path":"/foo/baz"}]`, err: true},
+		{target: `{"foo":"bar"}`, patch: `[{"op":"copy","path":"/baz"}]`, err: true},
+		{target: `{"foo":"bar"}`, patch: `[{"op":"remove"}]`, err: true},
+		{target: `{"foo":"bar"}`, patch: `[{"op":"remove","path":"foo"}]`, err: true},
+		{target: `{"foo":"bar"}`, patch: `[{"op":"update","path":"/foo"}]`, err: true},
+		{target: `{"foo":"bar"}`, patch: `{"foo":"baz"}`, err: true},
+	}
+	for _, test := range tests {
+		result, err := applyJSONPatch([]byte(test.target), []byte(test.patch))
+		if test.err {
+			Expect(err).To(HaveOccurred(), test.patch)
+			continue
+		}
+		Expect(err).NotTo(HaveOccurred(), test.patch)
+		Expect(result).To(MatchJSON(test.result), "%s + %s", test.target, test.patch)
+	}
+}
+
+func TestPatchBody(t *testing.T) {
+	RegisterTestingT(t)
+
+	id := "a-id"
+	kind := "Error"
+	href := "/errors/a-id"
+	reason := "a"
+	original := openapi.Error{Id: &id, Kind: &kind, Href: &href, Reason: &reason}
+
+	tests := []struct {
+		contentType string
+		patch       string
+		reason      string
+		code        errors.ServiceErrorCode
+	}{
+		{contentType: "", patch: `{"reason": "b"}`, reason: "b"},
+		{contentType: "application/json", patch: `{"reason": "b"}`, reason: "b"},
+		{contentType: "application/merge-patch+json; charset=utf-8", patch: `{"reason": "b"}`, reason: "b"},
+		{contentType: JSONPatchContentType, patch: `[{"op": "replace", "path": "/reason", "value": "b"}]`, reason: "b"},
+		{contentType: MergePatchContentType, patch: `{"href": null}`, code: errors.ErrorValidation},
+		{contentType: MergePatchContentType, patch: `{"kind": "Other"}`, code: errors.ErrorValidation},
+		{contentType: JSONPatchContentType, patch: `[{"op": "replace", "path": "/id", "value": "b-id"}]`, code: errors.ErrorValidation},
+		{contentType: JSONPatchContentType, patch: `[{"op": "replace", "path": "", "value": "a"}]`, code: errors.ErrorMalformedRequest},
+		{contentType: JSONPatchContentType, patch: `[{"op": "replace", "path": "/reason", "value": 1}]`, code: errors.ErrorMalformedRequest},
+		{contentType: JSONPatchContentType, patch: `[{"op": "remove", "path": "/missing"}]`, code: errors.ErrorMalformedRequest},
+		{contentType: MergePatchContentType, patch: `{ this is invalid }`, code: errors.ErrorMalformedRequest},
+		{contentType: "text/plain", patch: `{"reason": "b"}`, code: errors.ErrorBadRequest},
+	}
+	for _, test := range tests {
+		var object openapi.Error
+		cfg := &HandlerConfig{Body: &object, Patch: &Patch{}}
+		err := decodeBody(cfg, test.contentType, []byte(test.patch))
+		if err == nil {
+			err = cfg.Patch.Apply(original)
+		}
+		if test.code != 0 {
+			Expect(err).NotTo(BeNil(), test.patch)
+			Expect(err.Code).To(Equal(test.code), "%s: %s", test.patch, err)
+			continue
+		}
+		Expect(err).To(BeNil(), test.patch)
+		Expect(object.GetReason()).To(Equal(test.reason))
+		Expect(object.GetId()).To(Equal(id))
+	}
+}
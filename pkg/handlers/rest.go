@@ -9,3 +9,9 @@ type RestHandler interface {
 	Patch(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
 }
+
+// PutHandler is implemented by the handlers of the kinds which can be replaced with a PUT request,
+// see Created for the handlers which create the missing resources too
+type PutHandler interface {
+	Put(w http.ResponseWriter, r *http.Request)
+}
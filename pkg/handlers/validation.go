@@ -1,23 +1,49 @@
 package handlers
 
 import (
+	"net/http"
 	"reflect"
 	"strings"
 
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
 	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/validation"
 )
 
+// ValidateStruct checks the body against the rules of its validate tags, see the validation package,
+// and reports all the violations found at once, each one in the details of the error
+func ValidateStruct(i interface{}) Validate {
+	return func() *errors.ServiceError {
+		violations := validation.Struct(i)
+		if len(violations) == 0 {
+			return nil
+		}
+		details := make([]errors.FieldViolation, len(violations))
+		for i, violation := range violations {
+			details[i] = errors.FieldViolation{Field: violation.Field, Rule: violation.Rule, Message: violation.String()}
+		}
+		return errors.Validation("%s", violations.Error()).WithDetails(details...)
+	}
+}
+
+// fieldViolation returns a validation error about a single field, the reason being the message of its violation
+func fieldViolation(field string, rule string, message string, values ...interface{}) *errors.ServiceError {
+	err := errors.Validation(message, values...)
+	return err.WithDetails(errors.FieldViolation{Field: field, Rule: rule, Message: err.Reason})
+}
+
 func ValidateNotEmpty(i interface{}, fieldName string, field string) Validate {
 	return func() *errors.ServiceError {
 		value := reflect.ValueOf(i).Elem().FieldByName(fieldName)
 		if value.Kind() == reflect.Ptr {
 			if value.IsNil() {
-				return errors.Validation("%s is required", field)
+				return fieldViolation(field, "required", "%s is required", field)
 			}
 			value = value.Elem()
 		}
 		if len(value.String()) == 0 {
-			return errors.Validation("%s is required", field)
+			return fieldViolation(field, "required", "%s is required", field)
 		}
 		return nil
 	}
@@ -33,12 +59,45 @@ func ValidateEmpty(i interface{}, fieldName string, field string) Validate {
 			value = value.Elem()
 		}
 		if len(value.String()) != 0 {
-			return errors.Validation("%s must be empty", field)
+			return fieldViolation(field, "empty", "%s must be empty", field)
+		}
+		return nil
+	}
+}
+
+// ValidateIDMatches checks that the id held by the field, a string or a pointer to one, is either empty
+// or the given one, e.g. the id of the path of a PUT request
+func ValidateIDMatches(i interface{}, fieldName string, id string) Validate {
+	return func() *errors.ServiceError {
+		value := reflect.ValueOf(i).Elem().FieldByName(fieldName)
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return nil
+			}
+			value = value.Elem()
+		}
+		if value.String() != "" && value.String() != id {
+			return fieldViolation("id", "id_matches", "id must be empty or '%s', not '%s'", id, value.String())
 		}
 		return nil
 	}
 }
 
+// ValidateLabels checks the keys and values of the labels held by the field, a map or a pointer to one
+func ValidateLabels(i interface{}, fieldName string) Validate {
+	return func() *errors.ServiceError {
+		value := reflect.ValueOf(i).Elem().FieldByName(fieldName)
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return nil
+			}
+			value = value.Elem()
+		}
+		labels, _ := value.Interface().(map[string]string)
+		return api.ValidateLabels(labels)
+	}
+}
+
 // Note that because this uses strings.EqualFold, it is case-insensitive
 func ValidateInclusionIn(value *string, list []string, category *string) Validate {
 	return func() *errors.ServiceError {
@@ -50,6 +109,13 @@ func ValidateInclusionIn(value *string, list []string, category *string) Validat
 		if category == nil {
 			category = &[]string{"value"}[0]
 		}
-		return errors.Validation("%s is not a valid %s", *value, *category)
+		return fieldViolation(*category, "enum", "%s is not a valid %s", *value, *category)
+	}
+}
+
+// ValidateAdmin only lets the admins, see the --admin-users flag, run the action
+func ValidateAdmin(r *http.Request, action string) Validate {
+	return func() *errors.ServiceError {
+		return auth.CheckAdmin(r.Context(), action)
 	}
 }
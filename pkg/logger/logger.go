@@ -2,7 +2,9 @@ package logger
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/getsentry/sentry-go"
@@ -14,6 +16,7 @@ type OCMLogger interface {
 	V(level int32) OCMLogger
 	Infof(format string, args ...interface{})
 	Extra(key string, value interface{}) OCMLogger
+	WithError(err error) OCMLogger
 	Info(message string)
 	Warning(message string)
 	Error(message string)
@@ -32,6 +35,8 @@ type logger struct {
 	username  string
 	sentryHub *sentry.Hub
 	extra     extra
+	// err is reported to Sentry with the chain of its causes and their stacks
+	err error
 }
 
 // NewOCMLogger creates a new logger instance with a default verbosity of 1
@@ -108,6 +113,12 @@ func (l *logger) Extra(key string, value interface{}) OCMLogger {
 	return l
 }
 
+// WithError reports the error, its causes and the stacks they captured to Sentry with the next error message
+func (l *logger) WithError(err error) OCMLogger {
+	l.err = err
+	return l
+}
+
 func (l *logger) Info(message string) {
 	l.log(message, sentry.LevelInfo, glog.V(glog.Level(l.level)).Infoln)
 }
@@ -137,6 +148,9 @@ func (l *logger) captureSentryEvent(level sentry.Level, message string) {
 	event.Level = level
 	event.Message = message
 	event.Extra = l.extra
+	if l.err != nil {
+		event.Exception = exceptions(l.err)
+	}
 	captureFunc := sentry.CaptureEvent
 	if l.sentryHub == nil {
 		sentry.CaptureException(fmt.Errorf("sentry hub not present in logger"))
@@ -145,3 +159,19 @@ func (l *logger) captureSentryEvent(level sentry.Level, message string) {
 	}
 	captureFunc(event)
 }
+
+// maxErrorDepth is the number of causes of an error reported to Sentry at most
+const maxErrorDepth = 10
+
+// exceptions returns the error and its causes, the innermost first as Sentry expects them
+func exceptions(err error) []sentry.Exception {
+	var chain []sentry.Exception
+	for ; err != nil && len(chain) < maxErrorDepth; err = errors.Unwrap(err) {
+		chain = append([]sentry.Exception{{
+			Type:       reflect.TypeOf(err).String(),
+			Value:      err.Error(),
+			Stacktrace: sentry.ExtractStacktrace(err),
+		}}, chain...)
+	}
+	return chain
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/openshift-online/ocm-sdk-go/authentication"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/environments"
+	"github.com/openshift-online/rh-trex-ai/pkg/handlers"
 	"github.com/openshift-online/rh-trex-ai/pkg/trex"
 )
 
@@ -73,10 +74,12 @@ func NewDefaultAPIServer(env *environments.Env, specData []byte) Server {
 			http.MethodGet,
 			http.MethodPatch,
 			http.MethodPost,
+			http.MethodPut,
 		}),
 		gorillahandlers.AllowedHeaders([]string{
 			"Authorization",
 			"Content-Type",
+			handlers.IdempotencyKeyHeader,
 		}),
 		gorillahandlers.MaxAge(int((10 * time.Minute).Seconds())),
 	)(mainHandler)
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/environments"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+func init() {
+	db.RegisterMigration(idempotencyKeysMigration())
+}
+
+// NewIdempotencyKeyService returns the service storing the responses of the requests sent with an Idempotency-Key
+func NewIdempotencyKeyService(env *environments.Env) services.IdempotencyKeyService {
+	return services.NewIdempotencyKeyService(
+		db.NewAdvisoryLockFactory(env.Database.SessionFactory),
+		dao.NewIdempotencyKeyDao(&env.Database.SessionFactory),
+		env.Config.Server.IdempotencyKeyTTL,
+	)
+}
+
+// maxIdempotencyKeyExpiryInterval is how often the expired idempotency keys are deleted at most, whatever their TTL
+const maxIdempotencyKeyExpiryInterval = time.Hour
+
+// IdempotencyKeyExpiryServer periodically deletes the expired idempotency keys, whether or not the purge
+// of the soft-deleted records is enabled
+type IdempotencyKeyExpiryServer struct {
+	TTL                   time.Duration
+	IdempotencyKeyService services.IdempotencyKeyService
+}
+
+func NewDefaultIdempotencyKeyExpiryServer(env *environments.Env) *IdempotencyKeyExpiryServer {
+	return &IdempotencyKeyExpiryServer{
+		TTL:                   env.Config.Server.IdempotencyKeyTTL,
+		IdempotencyKeyService: NewIdempotencyKeyService(env),
+	}
+}
+
+func (s IdempotencyKeyExpiryServer) Start() {
+	log := logger.NewOCMLogger(context.Background())
+	if s.TTL <= 0 {
+		log.Infof("Expiry of idempotency keys is disabled")
+		return
+	}
+
+	interval := min(s.TTL, maxIdempotencyKeyExpiryInterval)
+	log.Infof("Deleting expired idempotency keys every %s", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.DeleteExpired(context.Background(), time.Now())
+		<-ticker.C
+	}
+}
+
+// DeleteExpired deletes the idempotency keys expired before now once
+func (s IdempotencyKeyExpiryServer) DeleteExpired(ctx context.Context, now time.Time) {
+	log := logger.NewOCMLogger(ctx)
+
+	deleted, serviceErr := s.IdempotencyKeyService.DeleteExpired(ctx, now)
+	if serviceErr != nil {
+		log.Error(fmt.Sprintf("Unable to delete expired idempotency keys: %s", serviceErr.Error()))
+		return
+	}
+	if deleted > 0 {
+		log.Infof("Deleted %d expired idempotency keys", deleted)
+	}
+}
+
+func idempotencyKeysMigration() *gormigrate.Migration {
+	type IdempotencyKey struct {
+		db.Model
+		Key         string `gorm:"uniqueIndex:idx_idempotency_keys_request"`
+		Username    string `gorm:"uniqueIndex:idx_idempotency_keys_request"`
+		Method      string `gorm:"uniqueIndex:idx_idempotency_keys_request"`
+		Path        string `gorm:"uniqueIndex:idx_idempotency_keys_request"`
+		RequestHash string
+		StatusCode  int
+		Response    []byte
+		ExpiresAt   time.Time `gorm:"index"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610191000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&IdempotencyKey{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&IdempotencyKey{})
+		},
+	}
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/config"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/environments"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+// purgeRegistry holds a model of every kind whose soft-deleted records are purged, by kind
+var purgeRegistry = make(map[string]interface{})
+
+// RegisterPurgeJob purges the records of a kind soft-deleted for longer than its retention,
+// model is a pointer to the database model of the kind, e.g. &Dinosaur{}
+func RegisterPurgeJob(kind string, model interface{}) {
+	purgeRegistry[kind] = model
+}
+
+// PurgeServer periodically and permanently deletes the records soft-deleted past their retention
+type PurgeServer struct {
+	Config         *config.PurgeConfig
+	GenericService services.GenericService
+	LockFactory    db.LockFactory
+}
+
+func NewDefaultPurgeServer(env *environments.Env) *PurgeServer {
+	return &PurgeServer{
+		Config:         env.Config.Purge,
+		GenericService: services.NewGenericService(dao.NewGenericDao(&env.Database.SessionFactory)),
+		LockFactory:    db.NewAdvisoryLockFactory(env.Database.SessionFactory),
+	}
+}
+
+func (s PurgeServer) Start() {
+	log := logger.NewOCMLogger(context.Background())
+	if !s.Config.EnablePurge {
+		log.Infof("Purge of deleted records is disabled")
+		return
+	}
+
+	log.Infof("Purging deleted records every %s", s.Config.Interval)
+	ticker := time.NewTicker(s.Config.Interval)
+	defer ticker.Stop()
+	for {
+		s.Purge(context.Background(), time.Now())
+		<-ticker.C
+	}
+}
+
+// Purge runs the purge job of every kind once, now tells when the retentions end
+func (s PurgeServer) Purge(ctx context.Context, now time.Time) {
+	for kind, model := range purgeRegistry {
+		s.purgeKind(ctx, kind, model, now)
+	}
+}
+
+func (s PurgeServer) purgeKind(ctx context.Context, kind string, model interface{}, now time.Time) {
+	log := logger.NewOCMLogger(ctx)
+
+	retention, err := s.Config.RetentionFor(kind)
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+
+	// the replicas purge every kind once, whichever gets it first
+	lockOwnerID, acquired, err := s.LockFactory.NewNonBlockingLock(ctx, kind, db.Purge)
+	defer s.LockFactory.Unlock(ctx, lockOwnerID)
+	if err != nil {
+		log.Error(fmt.Sprintf("Error obtaining the purge lock of %s: %v", kind, err))
+		return
+	}
+	if !acquired {
+		log.Infof("%s are purged by another worker", kind)
+		return
+	}
+
+	purged, serviceErr := s.GenericService.Purge(ctx, model, now.Add(-retention))
+	if serviceErr != nil {
+		log.Error(fmt.Sprintf("Unable to purge %s: %s", kind, serviceErr.Error()))
+		return
+	}
+	if purged > 0 {
+		log.Infof("Purged %d %s deleted more than %s ago", purged, kind, retention)
+	}
+}
@@ -10,14 +10,20 @@ import (
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/config"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
 	"github.com/openshift-online/rh-trex-ai/pkg/environments"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
 	"github.com/openshift-online/rh-trex-ai/pkg/handlers"
 	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 	"github.com/openshift-online/rh-trex-ai/pkg/server/logging"
 	"github.com/openshift-online/rh-trex-ai/pkg/trex"
 )
 
+// ResponseViolations are the responses which didn't match the OpenAPI specification, recorded when
+// --enable-response-validation is set as in the testing environments
+var ResponseViolations = &handlers.ResponseViolations{}
+
 func BuildDefaultRoutes(env *environments.Env, specData []byte) *mux.Router {
 	services := &env.Services
 
@@ -36,13 +42,23 @@ func BuildDefaultRoutes(env *environments.Env, specData []byte) *mux.Router {
 		Check(fmt.Errorf("auth middleware is nil"), "Unable to create auth middleware: missing middleware", env.Config.Sentry.Timeout)
 	}
 
+	// admins can list and restore deleted resources
+	auth.SetAdmins(env.Config.Server.AdminUsers)
+
+	// the routes declare the action and resource type the authorization backend checks
 	authzMiddleware := auth.NewAuthzMiddlewareMock()
 	if env.Config.Server.EnableAuthz {
+		authorizer, err := newAuthorizer(env)
+		if err != nil {
+			Check(err, "Unable to create authorization backend", env.Config.Sentry.Timeout)
+		}
+		authzMiddleware = auth.NewAuthzMiddleware(authorizer)
 	}
 
 	mainRouter := mux.NewRouter()
 	mainRouter.NotFoundHandler = http.HandlerFunc(api.SendNotFound)
 	mainRouter.Use(logger.OperationIDMiddleware)
+	mainRouter.Use(errors.ProblemNegotiationMiddleware)
 	mainRouter.Use(logging.RequestLoggingMiddleware)
 
 	apiPrefix := strings.TrimSuffix(trex.GetConfig().BasePath, "/v1")
@@ -58,15 +74,46 @@ func BuildDefaultRoutes(env *environments.Env, specData []byte) *mux.Router {
 	apiV1Router.HandleFunc("/openapi.html", openapiHandler.GetOpenAPIUI).Methods(http.MethodGet)
 	apiV1Router.HandleFunc("/openapi", openapiHandler.GetOpenAPI).Methods(http.MethodGet)
 
+	// the catalog of the errors, the href of the error responses, is public like the specification
+	errorsHandler := handlers.NewErrorsHandler()
+	apiV1Router.HandleFunc("/errors", errorsHandler.List).Methods(http.MethodGet)
+	apiV1Router.HandleFunc("/errors/{id}", errorsHandler.Get).Methods(http.MethodGet)
+
 	apiV1Router.Use(MetricsMiddleware)
+	if env.Config.Server.EnableRequestValidation {
+		requestValidationMiddleware, err := handlers.NewRequestValidationMiddleware(specData)
+		if err != nil {
+			Check(err, "Unable to create request validation middleware", env.Config.Sentry.Timeout)
+		}
+		apiV1Router.Use(requestValidationMiddleware)
+	}
 	apiV1Router.Use(
 		func(next http.Handler) http.Handler {
 			return db.TransactionMiddleware(next, env.Database.SessionFactory)
 		},
 	)
 	apiV1Router.Use(gorillahandlers.CompressHandler)
+	if env.Config.Server.EnableResponseValidation {
+		responseValidationMiddleware, err := handlers.NewResponseValidationMiddleware(specData, ResponseViolations)
+		if err != nil {
+			Check(err, "Unable to create response validation middleware", env.Config.Sentry.Timeout)
+		}
+		apiV1Router.Use(responseValidationMiddleware)
+	}
 
 	LoadDiscoveredRoutes(apiV1Router, services, authMiddleware, authzMiddleware)
 
 	return mainRouter
 }
+
+// newAuthorizer returns the authorization backend selected by --authz-backend
+func newAuthorizer(env *environments.Env) (auth.Authorizer, error) {
+	switch env.Config.Authz.Backend {
+	case config.AuthzBackendRBAC:
+		return auth.NewRBACAuthorizer(env.Config.Authz.PolicyFile, env.Config.Authz.PolicyReloadInterval)
+	case config.AuthzBackendAllowAll:
+		return auth.NewAllowAllAuthorizer(), nil
+	default:
+		return auth.NewOCMAuthorizer(env.Clients.OCM), nil
+	}
+}
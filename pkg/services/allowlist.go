@@ -0,0 +1,98 @@
+package services
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+// FieldAllowlist declares the fields of a kind its lists can be searched, sorted and projected on,
+// by the names the clients use in the search, orderBy and fields parameters, e.g. species or
+// owner.name. Kinds registering no allowlist keep every field allowed.
+type FieldAllowlist struct {
+	// Searchable fields can be used in search, and grouped or aggregated on
+	Searchable []string
+	// Sortable fields can be used in orderBy
+	Sortable []string
+	// Projectable fields can be requested with fields, id, kind and href always are
+	Projectable []string
+}
+
+var (
+	fieldAllowlists = map[string]FieldAllowlist{}
+	// the fields identifying a resource are presented whatever the projection
+	alwaysProjectable = []string{"id", "kind", "href"}
+)
+
+// RegisterFieldAllowlist restricts the fields the lists of a resource type can be searched, sorted
+// and projected on. Plugins register it next to their routes, it is published in the OpenAPI
+// description of their list endpoint.
+func RegisterFieldAllowlist(resourceType string, allowlist FieldAllowlist) {
+	fieldAllowlists[resourceType] = allowlist
+}
+
+// FieldAllowlists returns the registered allowlists by resource type
+func FieldAllowlists() map[string]FieldAllowlist {
+	allowlists := make(map[string]FieldAllowlist, len(fieldAllowlists))
+	for resourceType, allowlist := range fieldAllowlists {
+		allowlists[resourceType] = allowlist
+	}
+	return allowlists
+}
+
+// fieldAllowlist returns the allowlist of a resource type, nil when every field is allowed
+func fieldAllowlist(resourceType string) *FieldAllowlist {
+	allowlist, ok := fieldAllowlists[resourceType]
+	if !ok {
+		return nil
+	}
+	return &allowlist
+}
+
+// CheckSearchable fails when the field cannot be searched
+func (a *FieldAllowlist) CheckSearchable(field string) *errors.ServiceError {
+	if a != nil && !containsField(a.Searchable, field) {
+		return errors.FailedToParseSearch("%s is not a searchable field, the searchable fields are: %s",
+			field, describeFields(a.Searchable))
+	}
+	return nil
+}
+
+// CheckSortable fails when the field cannot be sorted on
+func (a *FieldAllowlist) CheckSortable(field string) *errors.ServiceError {
+	if a != nil && !containsField(a.Sortable, field) {
+		return errors.FailedToParseSearch("%s is not a sortable field, the sortable fields are: %s",
+			field, describeFields(a.Sortable))
+	}
+	return nil
+}
+
+// CheckProjectable fails when the field cannot be requested. The fields of a related resource,
+// e.g. owner.name, are allowed by the resource itself too.
+func (a *FieldAllowlist) CheckProjectable(field string) *errors.ServiceError {
+	if a == nil || containsField(alwaysProjectable, field) || containsField(a.Projectable, field) ||
+		containsField(a.Projectable, strings.Split(field, ".")[0]) {
+		return nil
+	}
+	return errors.FailedToParseSearch("%s is not a projectable field, the projectable fields are: %s",
+		field, describeFields(a.Projectable))
+}
+
+func containsField(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+func describeFields(fields []string) string {
+	if len(fields) == 0 {
+		return "none"
+	}
+	sorted := append([]string{}, fields...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
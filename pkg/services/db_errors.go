@@ -0,0 +1,130 @@
+package services
+
+import (
+	e "errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/lib/pq"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+// Postgres error codes translated to service errors, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation      = "23505"
+	pgForeignKeyViolation  = "23503"
+	pgCheckViolation       = "23514"
+	pgSerializationFailure = "40001"
+	pgLockNotAvailable     = "55P03"
+)
+
+var (
+	// keyRegex matches the columns of the constraint violation details, e.g. Key (species, era)=(Diplodocus, jurassic) already exists.
+	keyRegex = regexp.MustCompile(`^Key \((.+?)\)=\(.*\) (already exists|is not present in table "(.+?)"|is still referenced from table "(.+?)")`)
+	// constraintRegex matches the constraint of the violation messages, for the drivers not reporting it
+	constraintRegex = regexp.MustCompile(`constraint "(.+?)"`)
+)
+
+// dbError is a Postgres error reported by either pgconn, the driver of gorm, or lib/pq
+type dbError struct {
+	code       string
+	message    string
+	detail     string
+	column     string
+	constraint string
+}
+
+// asDBError returns the Postgres error in the chain of err, nil when there's none
+func asDBError(err error) *dbError {
+	var pgErr *pgconn.PgError
+	if e.As(err, &pgErr) {
+		return newDBError(pgErr.Code, pgErr.Message, pgErr.Detail, pgErr.ColumnName, pgErr.ConstraintName)
+	}
+	var pqErr *pq.Error
+	if e.As(err, &pqErr) {
+		return newDBError(string(pqErr.Code), pqErr.Message, pqErr.Detail, pqErr.Column, pqErr.Constraint)
+	}
+	return nil
+}
+
+func newDBError(code, message, detail, column, constraint string) *dbError {
+	if constraint == "" {
+		if match := constraintRegex.FindStringSubmatch(message); match != nil {
+			constraint = match[1]
+		}
+	}
+	return &dbError{code: code, message: message, detail: detail, column: column, constraint: constraint}
+}
+
+// columns returns the columns of the key in the detail of the violation, or the column of the error
+func (d *dbError) columns() []string {
+	if match := keyRegex.FindStringSubmatch(d.detail); match != nil {
+		return strings.Split(match[1], ", ")
+	}
+	if d.column != "" {
+		return []string{d.column}
+	}
+	return nil
+}
+
+// referencingTable returns the table still referencing the row of a foreign key violation, empty when
+// the violation is a missing referenced row
+func (d *dbError) referencingTable() string {
+	if match := keyRegex.FindStringSubmatch(d.detail); match != nil {
+		return match[4]
+	}
+	return ""
+}
+
+// withConstraint adds the name of the violated constraint, when known, to the reason
+func (d *dbError) withConstraint(reason string) string {
+	if d.constraint == "" {
+		return reason
+	}
+	return fmt.Sprintf("%s (%s)", reason, d.constraint)
+}
+
+// violations returns a field violation of the rule for each column of the error
+func (d *dbError) violations(rule, format string) []errors.FieldViolation {
+	var details []errors.FieldViolation
+	for _, column := range d.columns() {
+		details = append(details, errors.FieldViolation{
+			Field:   column,
+			Rule:    rule,
+			Message: fmt.Sprintf(format, column),
+		})
+	}
+	return details
+}
+
+// handleDBError translates the Postgres errors of the constraint violations, serialization failures and lock
+// timeouts to the service errors sent to the clients, uniqueReason being the reason of the unique violations.
+// It returns nil when err isn't one of them, and wraps err in the returned error.
+func handleDBError(resourceType string, err error, uniqueReason string) *errors.ServiceError {
+	dbErr := asDBError(err)
+	if dbErr == nil {
+		return nil
+	}
+	switch dbErr.code {
+	case pgUniqueViolation:
+		return errors.Conflict("%s", uniqueReason).
+			WithDetails(dbErr.violations("unique", "%s must be unique")...).WithCause(err)
+	case pgForeignKeyViolation:
+		if table := dbErr.referencingTable(); table != "" {
+			return errors.Conflict("%s is still referenced by %s", resourceType, table).WithCause(err)
+		}
+		return errors.Validation("%s", dbErr.withConstraint(resourceType+" references a missing resource")).
+			WithDetails(dbErr.violations("exists", "%s must reference an existing resource")...).WithCause(err)
+	case pgCheckViolation:
+		return errors.Validation("%s", dbErr.withConstraint(resourceType+" violates a check constraint")).
+			WithDetails(dbErr.violations("check", "%s is invalid")...).WithCause(err)
+	case pgSerializationFailure:
+		return errors.Conflict("%s was changed by a concurrent request, retry the request", resourceType).WithCause(err)
+	case pgLockNotAvailable:
+		return errors.Conflict("%s is locked by a concurrent request, retry the request", resourceType).WithCause(err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/lib/pq"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+func TestHandleDBError(t *testing.T) {
+	RegisterTestingT(t)
+
+	tests := []struct {
+		name     string
+		err      *errors.ServiceError
+		httpCode int
+		reason   string
+		details  []errors.FieldViolation
+	}{
+		{
+			name: "missing referenced row",
+			err: HandleCreateError("Dinosaur", &pgconn.PgError{
+				Code:           "23503",
+				Message:        `insert or update on table "dinosaurs" violates foreign key constraint "fk_dinosaurs_habitat"`,
+				Detail:         `Key (habitat_id)=(abc) is not present in table "habitats".`,
+				ConstraintName: "fk_dinosaurs_habitat",
+			}),
+			httpCode: http.StatusBadRequest,
+			reason:   "Dinosaur references a missing resource (fk_dinosaurs_habitat)",
+			details: []errors.FieldViolation{
+				{Field: "habitat_id", Rule: "exists", Message: "habitat_id must reference an existing resource"},
+			},
+		},
+		{
+			name: "row still referenced",
+			err: HandleDeleteError("Habitat", &pgconn.PgError{
+				Code:           "23503",
+				Message:        `update or delete on table "habitats" violates foreign key constraint "fk_dinosaurs_habitat" on table "dinosaurs"`,
+				Detail:         `Key (id)=(abc) is still referenced from table "dinosaurs".`,
+				ConstraintName: "fk_dinosaurs_habitat",
+			}),
+			httpCode: http.StatusConflict,
+			reason:   "Habitat is still referenced by dinosaurs",
+		},
+		{
+			name: "check violation reported by lib/pq",
+			err: HandleUpdateError("Dinosaur", fmt.Errorf("update: %w", &pq.Error{
+				Code:    "23514",
+				Message: `new row for relation "dinosaurs" violates check constraint "dinosaurs_weight_check"`,
+			})),
+			httpCode: http.StatusBadRequest,
+			reason:   "Dinosaur violates a check constraint (dinosaurs_weight_check)",
+		},
+		{
+			name: "unique violation reported by lib/pq",
+			err: HandleCreateError("Dinosaur", &pq.Error{
+				Code:       "23505",
+				Message:    `duplicate key value violates unique constraint "idx_dinosaurs_species"`,
+				Detail:     "Key (species)=(Diplodocus) already exists.",
+				Constraint: "idx_dinosaurs_species",
+			}),
+			httpCode: http.StatusConflict,
+			reason:   "This Dinosaur already exists",
+			details: []errors.FieldViolation{
+				{Field: "species", Rule: "unique", Message: "species must be unique"},
+			},
+		},
+		{
+			name:     "serialization failure",
+			err:      HandleUpdateError("Dinosaur", &pgconn.PgError{Code: "40001", Message: "could not serialize access due to concurrent update"}),
+			httpCode: http.StatusConflict,
+			reason:   "Dinosaur was changed by a concurrent request, retry the request",
+		},
+		{
+			name:     "lock timeout",
+			err:      HandleGetError("Dinosaur", "id", "abc", &pgconn.PgError{Code: "55P03", Message: "canceling statement due to lock timeout"}),
+			httpCode: http.StatusConflict,
+			reason:   "Dinosaur is locked by a concurrent request, retry the request",
+		},
+		{
+			name:     "other Postgres errors",
+			err:      HandleCreateError("Dinosaur", &pgconn.PgError{Code: "53300", Message: "too many connections"}),
+			httpCode: http.StatusInternalServerError,
+			reason:   "Unable to create Dinosaur",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			RegisterTestingT(t)
+			Expect(test.err.HttpCode).To(Equal(test.httpCode))
+			Expect(test.err.Reason).To(Equal(test.reason))
+			Expect(test.err.Details).To(Equal(test.details))
+			Expect(test.err.Unwrap()).NotTo(BeNil())
+		})
+	}
+}
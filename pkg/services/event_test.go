@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	daomocks "github.com/openshift-online/rh-trex-ai/pkg/dao/mocks"
+)
+
+// failingEventDao fails the deletes with err
+type failingEventDao struct {
+	dao.EventDao
+	err error
+}
+
+func (d *failingEventDao) Delete(ctx context.Context, id string) error {
+	return d.err
+}
+
+func (d *failingEventDao) All(ctx context.Context) (api.EventList, error) {
+	return nil, d.err
+}
+
+func (d *failingEventDao) FindByIDs(ctx context.Context, ids []string) (api.EventList, error) {
+	return nil, d.err
+}
+
+func TestEventDeleteDBError(t *testing.T) {
+	RegisterTestingT(t)
+
+	service := NewEventService(&failingEventDao{EventDao: daomocks.NewEventDao(), err: fmt.Errorf("delete: %w", &pgconn.PgError{
+		Code:    "23503",
+		Message: `update or delete on table "events" violates foreign key constraint "fk_event_instances_event" on table "event_instances"`,
+		Detail:  `Key (id)=(1) is still referenced from table "event_instances".`,
+	})})
+
+	err := service.Delete(context.Background(), "1")
+	Expect(err).NotTo(BeNil())
+	Expect(err.HttpCode).To(Equal(http.StatusConflict))
+	Expect(err.Reason).To(Equal("Event is still referenced by event_instances"))
+
+	// the other errors don't leak the database message
+	service = NewEventService(&failingEventDao{EventDao: daomocks.NewEventDao(), err: fmt.Errorf("connection refused")})
+	err = service.Delete(context.Background(), "1")
+	Expect(err.HttpCode).To(Equal(http.StatusInternalServerError))
+	Expect(err.Reason).To(Equal("Unable to delete Event"))
+}
+
+func TestEventListDBError(t *testing.T) {
+	RegisterTestingT(t)
+
+	cause := fmt.Errorf(`pq: relation "events" does not exist`)
+	service := NewEventService(&failingEventDao{EventDao: daomocks.NewEventDao(), err: cause})
+
+	// the database message is the cause, logged but not sent to the clients
+	_, err := service.All(context.Background())
+	Expect(err.Reason).To(Equal("Unable to get all events"))
+	Expect(err.Reason).NotTo(ContainSubstring("pq:"))
+	Expect(e.Is(err, cause)).To(BeTrue())
+
+	_, err = service.FindByIDs(context.Background(), []string{"1"})
+	Expect(err.Reason).To(Equal("Unable to get all events"))
+	Expect(e.Is(err, cause)).To(BeTrue())
+}
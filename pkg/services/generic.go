@@ -6,15 +6,16 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/yaacov/tree-search-language/pkg/tsl"
 	"github.com/yaacov/tree-search-language/pkg/walkers/ident"
-	sqlFilter "github.com/yaacov/tree-search-language/pkg/walkers/sql"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
 	"github.com/openshift-online/rh-trex-ai/pkg/dao"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
 	"github.com/openshift-online/rh-trex-ai/pkg/errors"
@@ -23,23 +24,36 @@ import (
 
 type GenericService interface {
 	List(ctx context.Context, username string, args *ListArguments, resourceList interface{}) (*api.PagingMeta, *errors.ServiceError)
+	Get(ctx context.Context, username string, id string, args *GetArguments, resource interface{}) *errors.ServiceError
+	Aggregate(ctx context.Context, username string, args *AggregateArguments, resource interface{}) ([]api.Aggregation, *errors.ServiceError)
+	Purge(ctx context.Context, resource interface{}, deletedBefore time.Time) (int64, *errors.ServiceError)
+	Transaction(ctx context.Context, fn func(ctx context.Context) *errors.ServiceError) *errors.ServiceError
 }
 
 func NewGenericService(genericDao dao.GenericDao) GenericService {
-	return &sqlGenericService{genericDao: genericDao}
+	return &sqlGenericService{genericDao: genericDao, clock: time.Now}
 }
 
 var _ GenericService = &sqlGenericService{}
 
 type sqlGenericService struct {
 	genericDao dao.GenericDao
+	// clock tells the time relative times in searches, e.g. now-24h, are evaluated at
+	clock func() time.Time
 }
 
 var (
 	SearchDisallowedFields = map[string]map[string]string{}
 	allFieldsAllowed       = map[string]string{}
+	textSearchFields       = map[string][]string{}
 )
 
+// RegisterTextSearchFields enables full-text search, e.g. ( species ~= 'big teeth' ), on the given
+// columns of a resource type. The columns are best backed by a GIN index, see db.TextSearchIndexSQL.
+func RegisterTextSearchFields(resourceType string, columns ...string) {
+	textSearchFields[resourceType] = append(textSearchFields[resourceType], columns...)
+}
+
 // wrap all needed pieces for the LIST funciton
 type listContext struct {
 	ctx              context.Context
@@ -49,15 +63,29 @@ type listContext struct {
 	ulog             *logger.OCMLogger
 	resourceList     interface{}
 	disallowedFields *map[string]string
+	allowlist        *FieldAllowlist
 	resourceType     string
-	joins            map[string]dao.TableRelation
+	joins            []relationJoin
 	groupBy          []string
 	set              map[string]bool
+	relevance        []string
+}
+
+// relationJoin is a related table joined under an alias named after its relation path,
+// e.g. owner.team is joined as "owner_team". Aliases keep two relations onto the same table apart.
+type relationJoin struct {
+	alias       string
+	parentAlias string
+	path        []string
+	relation    dao.TableRelation
 }
 
 func (s *sqlGenericService) newListContext(ctx context.Context, username string, args *ListArguments, resourceList interface{}) (*listContext, interface{}, *errors.ServiceError) {
+	return s.newResourceContext(ctx, username, args, reflect.TypeOf(resourceList).Elem().Elem(), resourceList)
+}
+
+func (s *sqlGenericService) newResourceContext(ctx context.Context, username string, args *ListArguments, resourceModel reflect.Type, resourceList interface{}) (*listContext, interface{}, *errors.ServiceError) {
 	log := logger.NewOCMLogger(ctx)
-	resourceModel := reflect.TypeOf(resourceList).Elem().Elem()
 	resourceTypeStr := resourceModel.Name()
 	if resourceTypeStr == "" {
 		return nil, nil, errors.GeneralError("Could not determine resource type")
@@ -75,6 +103,7 @@ func (s *sqlGenericService) newListContext(ctx context.Context, username string,
 		ulog:             &log,
 		resourceList:     resourceList,
 		disallowedFields: &disallowedFields,
+		allowlist:        fieldAllowlist(resourceTypeStr),
 		resourceType:     resourceTypeStr,
 	}, reflect.New(resourceModel).Interface(), nil
 }
@@ -88,12 +117,21 @@ func (s *sqlGenericService) List(ctx context.Context, username string, args *Lis
 
 	// the ordering for the sub functions matters.
 	builders := []listBuilder{
+		// include the soft-deleted resources, for admins only
+		s.buildDeleted,
+
 		// build SQL to load related resource. for now, it delegates to gorm.preload.
 		s.buildPreload,
 
+		// narrow "SELECT" to the columns behind the requested fields
+		s.buildSelect,
+
 		// add "ORDER BY"
 		s.buildOrderBy,
 
+		// translate "labelSelector" into a "WHERE" on the labels
+		s.buildLabelSelector,
+
 		// translate "search" into "WHERE"(s), and "JOIN"(s) if related resource is searched.
 		s.buildSearch,
 
@@ -119,9 +157,177 @@ func (s *sqlGenericService) List(ctx context.Context, username string, args *Lis
 	return listCtx.pagingMeta, nil
 }
 
+// Get resource must be a pointer to a database resource object
+func (s *sqlGenericService) Get(ctx context.Context, username string, id string, args *GetArguments, resource interface{}) *errors.ServiceError {
+	listArgs := &ListArguments{Preloads: args.Preloads, Fields: args.Fields}
+	listCtx, model, err := s.newResourceContext(ctx, username, listArgs, reflect.TypeOf(resource).Elem(), resource)
+	if err != nil {
+		return err
+	}
+
+	// only the builders shaping a single resource apply
+	builders := []listBuilder{
+		s.buildPreload,
+		s.buildSelect,
+	}
+
+	d := s.genericDao.GetInstanceDao(ctx, model)
+	for _, builderFn := range builders {
+		if _, err = builderFn(listCtx, &d); err != nil {
+			return err
+		}
+	}
+
+	if daoErr := d.Get(id, resource); daoErr != nil {
+		return HandleGetError(listCtx.resourceType, "id", id, daoErr)
+	}
+	return nil
+}
+
+// Aggregate resource must be a pointer to a database resource object, it tells the type of the resources to aggregate
+func (s *sqlGenericService) Aggregate(ctx context.Context, username string, args *AggregateArguments, resource interface{}) ([]api.Aggregation, *errors.ServiceError) {
+	listArgs := &ListArguments{Search: args.Search}
+	listCtx, model, err := s.newResourceContext(ctx, username, listArgs, reflect.TypeOf(resource).Elem(), resource)
+	if err != nil {
+		return nil, err
+	}
+
+	d := s.genericDao.GetInstanceDao(ctx, model)
+	resourceTable := d.GetTableName()
+
+	// fields are requested by their json name, the same fields as the search may use can be aggregated
+	column := func(field string) (string, *errors.ServiceError) {
+		if err := listCtx.allowlist.CheckSearchable(field); err != nil {
+			return "", err
+		}
+		column, ok := d.GetColumnName(field)
+		if !ok {
+			return "", errors.BadRequest("%s is not a valid field name", field)
+		}
+		if _, disallowed := (*listCtx.disallowedFields)[column]; disallowed {
+			return "", errors.BadRequest("%s is not a valid field name", field)
+		}
+		return fmt.Sprintf("%s.%s", resourceTable, column), nil
+	}
+
+	// every aggregated value is selected under an alias which tells where it goes in the results
+	type aggregatedField struct {
+		alias string
+		field string
+		into  func(*api.Aggregation) map[string]interface{}
+	}
+	var aggregated []aggregatedField
+	var groupBy []string
+	// the joins of related resources repeat rows, the resources are counted once
+	selects := []string{fmt.Sprintf("COUNT(DISTINCT %s.id) AS count", resourceTable)}
+	for _, field := range args.GroupBy {
+		column, err := column(field)
+		if err != nil {
+			return nil, err
+		}
+		alias := fmt.Sprintf("group_%d", len(groupBy))
+		groupBy = append(groupBy, column)
+		selects = append(selects, fmt.Sprintf("%s AS %s", column, alias))
+		aggregated = append(aggregated, aggregatedField{alias, field, func(a *api.Aggregation) map[string]interface{} { return a.Group }})
+	}
+	for i, field := range args.Min {
+		column, err := column(field)
+		if err != nil {
+			return nil, err
+		}
+		alias := fmt.Sprintf("min_%d", i)
+		selects = append(selects, fmt.Sprintf("MIN(%s) AS %s", column, alias))
+		aggregated = append(aggregated, aggregatedField{alias, field, func(a *api.Aggregation) map[string]interface{} { return a.Min }})
+	}
+	for i, field := range args.Max {
+		column, err := column(field)
+		if err != nil {
+			return nil, err
+		}
+		alias := fmt.Sprintf("max_%d", i)
+		selects = append(selects, fmt.Sprintf("MAX(%s) AS %s", column, alias))
+		aggregated = append(aggregated, aggregatedField{alias, field, func(a *api.Aggregation) map[string]interface{} { return a.Max }})
+	}
+
+	// aggregate the resources matching the search only
+	sql, values, err := s.buildSearchValues(listCtx, &d)
+	if err != nil {
+		return nil, err
+	}
+	d.Where(dao.NewWhere(sql, values))
+
+	var rows []map[string]interface{}
+	if daoErr := d.Aggregate(selects, groupBy, &rows); daoErr != nil {
+		return nil, errors.GeneralError("Unable to aggregate resources").WithCause(daoErr).WithStack()
+	}
+
+	aggregations := make([]api.Aggregation, 0, len(rows))
+	for _, row := range rows {
+		aggregation := api.Aggregation{
+			Group: map[string]interface{}{},
+			Min:   map[string]interface{}{},
+			Max:   map[string]interface{}{},
+		}
+		if count, ok := row["count"].(int64); ok {
+			aggregation.Count = count
+		}
+		for _, f := range aggregated {
+			f.into(&aggregation)[f.field] = row[f.alias]
+		}
+		aggregations = append(aggregations, aggregation)
+	}
+	return aggregations, nil
+}
+
+// Purge resource must be a pointer to a database resource object, the resources of its type
+// soft-deleted before deletedBefore are permanently deleted
+func (s *sqlGenericService) Purge(ctx context.Context, resource interface{}, deletedBefore time.Time) (int64, *errors.ServiceError) {
+	d := s.genericDao.GetInstanceDao(ctx, resource)
+	purged, err := d.Purge(deletedBefore)
+	if err != nil {
+		return 0, errors.GeneralError("Unable to purge deleted resources").WithCause(err).WithStack()
+	}
+	return purged, nil
+}
+
+// Transaction runs fn in a single database transaction, the services given its context join it.
+// Everything fn did is rolled back when it fails.
+func (s *sqlGenericService) Transaction(ctx context.Context, fn func(ctx context.Context) *errors.ServiceError) *errors.ServiceError {
+	var serviceErr *errors.ServiceError
+	err := s.genericDao.Transaction(ctx, func(ctx context.Context) error {
+		serviceErr = fn(ctx)
+		if serviceErr != nil {
+			return serviceErr.AsError()
+		}
+		return nil
+	})
+	if serviceErr != nil {
+		return serviceErr
+	}
+	if err != nil {
+		return errors.GeneralError("Unable to run the transaction").WithCause(err).WithStack()
+	}
+	return nil
+}
+
 /*** Define all sub functions in the type of listBuilder ***/
 type listBuilder func(*listContext, *dao.GenericDao) (finished bool, err *errors.ServiceError)
 
+func (s *sqlGenericService) buildDeleted(listCtx *listContext, d *dao.GenericDao) (bool, *errors.ServiceError) {
+	if !listCtx.args.IncludeDeleted && !listCtx.args.OnlyDeleted {
+		return false, nil
+	}
+	if err := auth.CheckAdmin(listCtx.ctx, "list deleted resources"); err != nil {
+		return false, err
+	}
+
+	(*d).Unscoped()
+	if listCtx.args.OnlyDeleted {
+		(*d).Where(dao.NewWhere(fmt.Sprintf("%s.deleted_at IS NOT NULL", (*d).GetTableName()), nil))
+	}
+	return false, nil
+}
+
 func (s *sqlGenericService) buildPreload(listCtx *listContext, d *dao.GenericDao) (bool, *errors.ServiceError) {
 	listCtx.set = make(map[string]bool)
 
@@ -130,14 +336,82 @@ func (s *sqlGenericService) buildPreload(listCtx *listContext, d *dao.GenericDao
 	}
 	// preload each table only once; struct{} doesn't occupy any additional space
 	for _, preload := range listCtx.args.Preloads {
-		(*d).Preload(preload)
+		// related resources are requested by their json name, gorm preloads them by field name
+		relations, ok := (*d).GetPreloadRelationPath(strings.Split(preload, "."))
+		if !ok {
+			return false, errors.BadRequest("%s is not a related resource of %s", preload, listCtx.resourceType)
+		}
+		names := make([]string, 0, len(relations))
+		for _, relation := range relations {
+			names = append(names, relation.Name)
+		}
+		(*d).Preload(strings.Join(names, "."))
 	}
 	return false, nil
 }
 
+// translate the requested json fields into the columns to load. Fields which are not
+// columns (e.g. kind, href) are computed by the presenters and need nothing from the table,
+// related resources only need the column which joins them to this one.
+func (s *sqlGenericService) buildSelect(listCtx *listContext, d *dao.GenericDao) (bool, *errors.ServiceError) {
+	if len(listCtx.args.Fields) == 0 {
+		return false, nil
+	}
+
+	resourceTable := (*d).GetTableName()
+	selected := map[string]bool{}
+	var columns []string
+	addColumn := func(column string) {
+		if !selected[column] {
+			selected[column] = true
+			columns = append(columns, fmt.Sprintf("%s.%s", resourceTable, column))
+		}
+	}
+
+	// the primary key is always needed to present the resource and load its relations
+	addColumn("id")
+	for _, preload := range listCtx.args.Preloads {
+		// nested includes, e.g. owner.team, are loaded through the first relation
+		if relations, ok := (*d).GetPreloadRelationPath(strings.Split(preload, ".")[:1]); ok {
+			addColumn(relations[0].ColumnName)
+		}
+	}
+	for _, field := range listCtx.args.Fields {
+		if err := listCtx.allowlist.CheckProjectable(field); err != nil {
+			return false, err
+		}
+		name := strings.Split(field, ".")[0]
+		if column, ok := (*d).GetColumnName(name); ok {
+			addColumn(column)
+		} else if relations, ok := (*d).GetPreloadRelationPath([]string{name}); ok {
+			addColumn(relations[0].ColumnName)
+		}
+	}
+
+	(*d).Select(columns)
+	return false, nil
+}
+
 func (s *sqlGenericService) buildOrderBy(listCtx *listContext, d *dao.GenericDao) (bool, *errors.ServiceError) {
 	if len(listCtx.args.OrderBy) != 0 {
-		orderByArgs, serviceErr := db.ArgsToOrderBy(listCtx.args.OrderBy, *listCtx.disallowedFields)
+		// order by related fields, e.g. owner.team.name, joins the related tables like searching does
+		orderBy := make([]string, 0, len(listCtx.args.OrderBy))
+		for _, orderByArg := range listCtx.args.OrderBy {
+			order := strings.Split(strings.Trim(orderByArg, " "), " ")
+			if serviceErr := listCtx.allowlist.CheckSortable(order[0]); serviceErr != nil {
+				return false, serviceErr
+			}
+			field, err := s.resolveField(listCtx, d, order[0])
+			if err != nil {
+				return false, errors.BadRequest("%s", err.Error())
+			}
+			if !strings.Contains(field, ".") {
+				field = fmt.Sprintf("%s.%s", (*d).GetTableName(), field)
+			}
+			order[0] = field
+			orderBy = append(orderBy, strings.Join(order, " "))
+		}
+		orderByArgs, serviceErr := db.ArgsToOrderBy(orderBy, *listCtx.disallowedFields)
 		if serviceErr != nil {
 			return false, serviceErr
 		}
@@ -148,6 +422,28 @@ func (s *sqlGenericService) buildOrderBy(listCtx *listContext, d *dao.GenericDao
 	return false, nil
 }
 
+// select the resources by their labels, the kind must embed api.Labeled
+func (s *sqlGenericService) buildLabelSelector(listCtx *listContext, d *dao.GenericDao) (bool, *errors.ServiceError) {
+	if listCtx.args.LabelSelector == "" {
+		return false, nil
+	}
+	if _, ok := (*d).GetColumnName(db.LabelsColumn); !ok {
+		return false, errors.BadRequest("%s has no labels to select", listCtx.resourceType)
+	}
+
+	column := fmt.Sprintf("%s.%s", (*d).GetTableName(), db.LabelsColumn)
+	sqlizer, serviceErr := db.LabelSelectorSqlizer(column, listCtx.args.LabelSelector)
+	if serviceErr != nil {
+		return false, serviceErr
+	}
+	sql, values, err := sqlizer.ToSql()
+	if err != nil {
+		return false, errors.GeneralError("%s", err.Error())
+	}
+	(*d).Where(dao.NewWhere(sql, values))
+	return false, nil
+}
+
 func (s *sqlGenericService) buildSearchValues(listCtx *listContext, d *dao.GenericDao) (string, []any, *errors.ServiceError) {
 	if listCtx.args.Search == "" {
 		s.addJoins(listCtx, d)
@@ -155,12 +451,17 @@ func (s *sqlGenericService) buildSearchValues(listCtx *listContext, d *dao.Gener
 	}
 
 	// create the TSL tree
-	tslTree, err := tsl.ParseTSL(listCtx.args.Search)
+	tslTree, err := tsl.ParseTSL(db.QuoteRelativeTimes(listCtx.args.Search))
 	if err != nil {
 		return "", nil, errors.BadRequest("Failed to parse search query: %s", listCtx.args.Search)
 	}
+	// only the fields the kind allows can be searched
+	tslTree, serviceErr := s.treeWalkForAllowedFields(listCtx, tslTree)
+	if serviceErr != nil {
+		return "", nil, serviceErr
+	}
 	// find all related tables
-	tslTree, serviceErr := s.treeWalkForRelatedTables(listCtx, tslTree, d)
+	tslTree, serviceErr = s.treeWalkForRelatedTables(listCtx, tslTree, d)
 	if serviceErr != nil {
 		return "", nil, serviceErr
 	}
@@ -169,8 +470,13 @@ func (s *sqlGenericService) buildSearchValues(listCtx *listContext, d *dao.Gener
 	if serviceErr != nil {
 		return "", nil, serviceErr
 	}
+	// evaluate relative times, e.g. now-24h, compared with timestamp columns
+	tslTree, serviceErr = s.treeWalkForRelativeTimes(listCtx, tslTree, d)
+	if serviceErr != nil {
+		return "", nil, serviceErr
+	}
 	// convert to sqlizer
-	_, sqlizer, serviceErr := s.treeWalkForSqlizer(listCtx, tslTree)
+	_, sqlizer, serviceErr := s.treeWalkForSqlizer(listCtx, tslTree, d)
 	if serviceErr != nil {
 		return "", nil, serviceErr
 	}
@@ -191,32 +497,78 @@ func (s *sqlGenericService) buildSearch(listCtx *listContext, d *dao.GenericDao)
 		return false, err
 	}
 	(*d).Where(dao.NewWhere(sql, values))
+	if len(listCtx.groupBy) > 0 {
+		(*d).Group(strings.Join(listCtx.groupBy, ","))
+	}
+
+	// without an explicit order, full-text search lists the most relevant results first
+	if len(listCtx.args.OrderBy) == 0 && len(listCtx.relevance) > 0 {
+		(*d).OrderBy(fmt.Sprintf("%s desc", strings.Join(listCtx.relevance, " + ")))
+	}
 	return true, nil
 }
 
-// JOIN the tables that appear in the search string
+// JOIN the tables that appear in the search string or the order by
 func (s *sqlGenericService) addJoins(listCtx *listContext, d *dao.GenericDao) {
-	for _, r := range listCtx.joins {
-		if _, ok := listCtx.set[r.ForeignTableName]; ok {
-			// skip already included preloads
-			continue
-		}
+	for _, j := range listCtx.joins {
+		r := j.relation
 		sql := fmt.Sprintf(
-			"LEFT JOIN %s ON %s.%s = %s.%s AND %s.deleted_at IS NULL",
-			r.ForeignTableName, r.ForeignTableName, r.ForeignColumnName, r.TableName, r.ColumnName, r.ForeignTableName)
+			"LEFT JOIN %s AS %s ON %s.%s = %s.%s AND %s.deleted_at IS NULL",
+			r.ForeignTableName, j.alias, j.alias, r.ForeignColumnName, j.parentAlias, r.ColumnName, j.alias)
 		(*d).Joins(sql)
 
-		listCtx.groupBy = append(listCtx.groupBy, r.ForeignTableName+".id")
-		listCtx.set[r.ForeignTableName] = true
+		listCtx.groupBy = append(listCtx.groupBy, j.alias+".id")
 	}
 	if len(listCtx.joins) > 0 {
 		// Add base relation
 		listCtx.groupBy = append(listCtx.groupBy, (*d).GetTableName()+".id")
-		(*d).Group(strings.Join(listCtx.groupBy, ","))
 	}
 
 	// Reset list of joins and group by's
-	listCtx.joins = map[string]dao.TableRelation{}
+	listCtx.joins = nil
+}
+
+// join every relation along the path, e.g. owner.team, once and return the alias of the last one
+func (s *sqlGenericService) joinRelationPath(listCtx *listContext, d *dao.GenericDao, path []string) (string, error) {
+	relations, ok := (*d).GetTableRelationPath(path)
+	if !ok {
+		return "", fmt.Errorf("%s is not a related resource of %s", strings.Join(path, "."), listCtx.resourceType)
+	}
+
+	parentAlias := (*d).GetTableName()
+	for i, relation := range relations {
+		alias := fmt.Sprintf("%q", strings.Join(path[:i+1], "_"))
+		joined := false
+		for _, j := range listCtx.joins {
+			if j.alias == alias {
+				joined = true
+				break
+			}
+		}
+		if !joined {
+			listCtx.joins = append(listCtx.joins, relationJoin{
+				alias:       alias,
+				parentAlias: parentAlias,
+				path:        append([]string{}, path[:i+1]...),
+				relation:    relation,
+			})
+		}
+		parentAlias = alias
+	}
+	return parentAlias, nil
+}
+
+// replace the relation path of a field, e.g. owner.team.name, by the alias of its joined table
+func (s *sqlGenericService) resolveField(listCtx *listContext, d *dao.GenericDao, field string) (string, error) {
+	fieldParts := strings.Split(field, ".")
+	if len(fieldParts) == 1 || fieldParts[0] == (*d).GetTableName() {
+		return field, nil
+	}
+	alias, err := s.joinRelationPath(listCtx, d, fieldParts[:len(fieldParts)-1])
+	if err != nil {
+		return field, err
+	}
+	return fmt.Sprintf("%s.%s", alias, fieldParts[len(fieldParts)-1]), nil
 }
 
 func (s *sqlGenericService) loadList(listCtx *listContext, d *dao.GenericDao) *errors.ServiceError {
@@ -248,7 +600,7 @@ func (s *sqlGenericService) loadList(listCtx *listContext, d *dao.GenericDao) *e
 		if e.Is(err, gorm.ErrRecordNotFound) {
 			listCtx.pagingMeta.Size = 0
 		} else {
-			return errors.GeneralError("Unable to list resources: %s", err)
+			return errors.GeneralError("Unable to list resources").WithCause(err).WithStack()
 		}
 	}
 	listCtx.pagingMeta.Size = int64(reflect.ValueOf(listCtx.resourceList).Elem().Len())
@@ -271,33 +623,39 @@ func zeroSlice(i interface{}, cap int64) *errors.ServiceError {
 	return nil
 }
 
-// walk the TSL tree looking for fields like, e.g., creator.username, and then:
-// (1) look up the related table by its 1st part - creator
-// (2) replace it by table name - creator.username -> accounts.username
-func (s *sqlGenericService) treeWalkForRelatedTables(listCtx *listContext, tslTree tsl.Node, genericDao *dao.GenericDao) (tsl.Node, *errors.ServiceError) {
-	resourceTable := (*genericDao).GetTableName()
-	if listCtx.joins == nil {
-		listCtx.joins = map[string]dao.TableRelation{}
+// walk the TSL tree checking every searched field, as the client wrote it, is allowed for the kind
+func (s *sqlGenericService) treeWalkForAllowedFields(listCtx *listContext, tslTree tsl.Node) (tsl.Node, *errors.ServiceError) {
+	if listCtx.allowlist == nil {
+		return tslTree, nil
 	}
+
+	var serviceErr *errors.ServiceError
 	walkFn := func(field string) (string, error) {
-		fieldParts := strings.Split(field, ".")
-		if len(fieldParts) > 1 && fieldParts[0] != resourceTable {
-			fieldName := fieldParts[0]
-			_, exists := listCtx.joins[fieldName]
-			if !exists {
-				if relation, ok := (*genericDao).GetTableRelation(fieldName); ok {
-					listCtx.joins[fieldName] = relation
-				} else {
-					return field, fmt.Errorf("%s is not a related resource of %s", fieldName, listCtx.resourceType)
-				}
-			}
-			//replace by table name
-			fieldParts[0] = listCtx.joins[fieldName].ForeignTableName
-			return strings.Join(fieldParts, "."), nil
+		if serviceErr = listCtx.allowlist.CheckSearchable(field); serviceErr != nil {
+			return field, serviceErr.AsError()
 		}
 		return field, nil
 	}
 
+	tslTree, err := ident.Walk(tslTree, walkFn)
+	if serviceErr != nil {
+		return tslTree, serviceErr
+	}
+	if err != nil {
+		return tslTree, errors.BadRequest("%s", err.Error())
+	}
+
+	return tslTree, nil
+}
+
+// walk the TSL tree looking for fields like, e.g., creator.username or owner.team.name, and then:
+// (1) look up the related tables along the path - creator, owner -> team
+// (2) replace the path by the alias of the last joined table - owner.team.name -> "owner_team".name
+func (s *sqlGenericService) treeWalkForRelatedTables(listCtx *listContext, tslTree tsl.Node, genericDao *dao.GenericDao) (tsl.Node, *errors.ServiceError) {
+	walkFn := func(field string) (string, error) {
+		return s.resolveField(listCtx, genericDao, field)
+	}
+
 	tslTree, err := ident.Walk(tslTree, walkFn)
 	if err != nil {
 		return tslTree, errors.BadRequest("%s", err.Error())
@@ -329,18 +687,54 @@ func (s *sqlGenericService) treeWalkForAddingTableName(listCtx *listContext, tsl
 	return tslTree, nil
 }
 
-func (s *sqlGenericService) treeWalkForSqlizer(listCtx *listContext, tslTree tsl.Node) (tsl.Node, squirrel.Sqlizer, *errors.ServiceError) {
+// replace relative times by absolute ones, they only apply to the timestamp columns
+// of the resource or of its related resources
+func (s *sqlGenericService) treeWalkForRelativeTimes(listCtx *listContext, tslTree tsl.Node, d *dao.GenericDao) (tsl.Node, *errors.ServiceError) {
+	resourceTable := (*d).GetTableName()
+	isTimestamp := func(field string) bool {
+		dot := strings.LastIndex(field, ".")
+		if dot < 0 {
+			return false
+		}
+		table, column := field[:dot], field[dot+1:]
+		if table == resourceTable {
+			return (*d).IsTimestampColumn(nil, column)
+		}
+		for _, j := range listCtx.joins {
+			if j.alias == table {
+				return (*d).IsTimestampColumn(j.path, column)
+			}
+		}
+		return false
+	}
+
+	now := time.Now
+	if s.clock != nil {
+		now = s.clock
+	}
+	return db.RelativeTimeWalk(tslTree, isTimestamp, now())
+}
+
+func (s *sqlGenericService) treeWalkForSqlizer(listCtx *listContext, tslTree tsl.Node, d *dao.GenericDao) (tsl.Node, squirrel.Sqlizer, *errors.ServiceError) {
 	// Check field names in tree
 	tslTree, serviceErr := db.FieldNameWalk(tslTree, *listCtx.disallowedFields)
 	if serviceErr != nil {
 		return tslTree, nil, serviceErr
 	}
 
+	// full-text search applies to the registered columns of the resource table
+	resourceTable := (*d).GetTableName()
+	fullTextFields := map[string]bool{}
+	for _, column := range textSearchFields[listCtx.resourceType] {
+		fullTextFields[fmt.Sprintf("%s.%s", resourceTable, column)] = true
+	}
+
 	// Convert the search tree into SQL [Squirrel] filter
-	sqlizer, err := sqlFilter.Walk(tslTree)
-	if err != nil {
-		return tslTree, nil, errors.BadRequest("%s", err.Error())
+	sqlizer, relevance, serviceErr := db.SqlizerWalk(tslTree, fullTextFields)
+	if serviceErr != nil {
+		return tslTree, nil, serviceErr
 	}
+	listCtx.relevance = relevance
 
 	return tslTree, sqlizer, nil
 }
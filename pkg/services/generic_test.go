@@ -2,16 +2,22 @@ package services
 
 import (
 	"context"
+	"net/url"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/dao"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
 	dbmocks "github.com/openshift-online/rh-trex-ai/pkg/db/mocks"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/onsi/gomega/types"
 	"github.com/yaacov/tree-search-language/pkg/tsl"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
 	"github.com/openshift-online/rh-trex-ai/pkg/errors"
 
 	. "github.com/onsi/gomega"
@@ -19,11 +25,55 @@ import (
 
 type testModel struct {
 	api.Meta
+	api.Labeled
 	Species string
 }
 
 func (testModel) TableName() string { return "dinosaurs" }
 
+type testTeam struct {
+	api.Meta
+	Name string
+}
+
+func (testTeam) TableName() string { return "teams" }
+
+type testOwner struct {
+	api.Meta
+	Name   string
+	TeamID string
+	Team   *testTeam
+	Pets   []testPet `gorm:"foreignKey:OwnerID"`
+}
+
+func (testOwner) TableName() string { return "owners" }
+
+type testPet struct {
+	api.Meta
+	Species string
+	OwnerID string
+	Owner   *testOwner
+	Collar  *testCollar `gorm:"foreignKey:PetID"`
+}
+
+func (testPet) TableName() string { return "pets" }
+
+type testCollar struct {
+	api.Meta
+	PetID string
+}
+
+func (testCollar) TableName() string { return "collars" }
+
+// gorm names the join table fields of a many_to_many relation after the model, which must be exported
+type TestShow struct {
+	api.Meta
+	Name string
+	Pets []testPet `gorm:"many2many:show_pets;joinForeignKey:ShowID;joinReferences:PetID"`
+}
+
+func (TestShow) TableName() string { return "shows" }
+
 func TestSQLTranslation(t *testing.T) {
 	RegisterTestingT(t)
 	var dbFactory db.SessionFactory = dbmocks.NewMockSessionFactory()
@@ -64,17 +114,23 @@ func TestSQLTranslation(t *testing.T) {
 			"sql":    "username IN (?)",
 			"values": ConsistOf("ooo.openshift"),
 		},
+		{
+			"search": "username ilike 'OOO.%'",
+			"sql":    "username ILIKE ?",
+			"values": ConsistOf("OOO.%"),
+		},
 	}
 	for _, test := range tests {
 		var list []testModel
 		search := test["search"].(string)
 		sqlReal := test["sql"].(string)
 		valuesReal := test["values"].(types.GomegaMatcher)
-		listCtx, _, serviceErr := genericService.newListContext(context.Background(), "", &ListArguments{Search: search}, &list)
+		listCtx, model, serviceErr := genericService.newListContext(context.Background(), "", &ListArguments{Search: search}, &list)
 		Expect(serviceErr).ToNot(HaveOccurred())
+		d := g.GetInstanceDao(context.Background(), model)
 		tslTree, err := tsl.ParseTSL(search)
 		Expect(err).ToNot(HaveOccurred())
-		_, sqlizer, serviceErr := genericService.treeWalkForSqlizer(listCtx, tslTree)
+		_, sqlizer, serviceErr := genericService.treeWalkForSqlizer(listCtx, tslTree, &d)
 		Expect(serviceErr).ToNot(HaveOccurred())
 		sql, values, err := sqlizer.ToSql()
 		Expect(err).ToNot(HaveOccurred())
@@ -82,3 +138,596 @@ func TestSQLTranslation(t *testing.T) {
 		Expect(values).To(valuesReal)
 	}
 }
+
+func TestSelectTranslation(t *testing.T) {
+	RegisterTestingT(t)
+	var dbFactory db.SessionFactory = dbmocks.NewMockSessionFactory()
+	defer dbFactory.Close()
+
+	g := dao.NewGenericDao(&dbFactory)
+	genericService := sqlGenericService{genericDao: g}
+
+	tests := []map[string]interface{}{
+		{
+			"fields": []string{"species", "kind", "href", "id"},
+			"select": `SELECT dinosaurs.id,dinosaurs.species FROM "dinosaurs"`,
+		},
+		{
+			"fields": []string{"created_at", "id"},
+			"select": `SELECT dinosaurs.id,dinosaurs.created_at FROM "dinosaurs"`,
+		},
+		{
+			"fields": []string{},
+			"select": `SELECT * FROM "dinosaurs"`,
+		},
+	}
+	for _, test := range tests {
+		var list []testModel
+		fields := test["fields"].([]string)
+		listCtx, model, serviceErr := genericService.newListContext(context.Background(), "", &ListArguments{Fields: fields}, &list)
+		Expect(serviceErr).ToNot(HaveOccurred())
+		d := g.GetInstanceDao(context.Background(), model)
+		_, serviceErr = genericService.buildSelect(listCtx, &d)
+		Expect(serviceErr).ToNot(HaveOccurred())
+
+		// sqlmock rejects the unexpected query, reporting the statement it was given
+		err := d.Fetch(0, 1, &list)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(test["select"].(string)))
+	}
+}
+
+func TestSelectNestedInclude(t *testing.T) {
+	RegisterTestingT(t)
+	var dbFactory db.SessionFactory = dbmocks.NewMockSessionFactory()
+	defer dbFactory.Close()
+
+	g := dao.NewGenericDao(&dbFactory)
+	genericService := sqlGenericService{genericDao: g}
+
+	var list []testPet
+	args := &ListArguments{Fields: []string{"species", "id"}, Preloads: []string{"owner.team"}}
+	listCtx, model, serviceErr := genericService.newListContext(context.Background(), "", args, &list)
+	Expect(serviceErr).ToNot(HaveOccurred())
+	d := g.GetInstanceDao(context.Background(), model)
+	_, serviceErr = genericService.buildSelect(listCtx, &d)
+	Expect(serviceErr).ToNot(HaveOccurred())
+
+	// the owner, and through it the team, can only be loaded with the owner_id column
+	err := d.Fetch(0, 1, &list)
+	Expect(err).To(HaveOccurred())
+	Expect(err.Error()).To(ContainSubstring(`SELECT pets.id,pets.owner_id,pets.species FROM "pets"`))
+}
+
+func TestPreloadRelationKinds(t *testing.T) {
+	RegisterTestingT(t)
+	var dbFactory db.SessionFactory = dbmocks.NewMockSessionFactory()
+	defer dbFactory.Close()
+
+	g := dao.NewGenericDao(&dbFactory)
+	genericService := sqlGenericService{genericDao: g}
+
+	// has_one and many_to_many relations can be included, though they can't be joined
+	tests := []map[string]interface{}{
+		{
+			"list":     &[]testPet{},
+			"preloads": []string{"collar", "owner.pets"},
+			"select":   `SELECT pets.id,pets.owner_id,pets.species FROM "pets"`,
+			"search":   "collar.id = 'x'",
+		},
+		{
+			"list":     &[]TestShow{},
+			"preloads": []string{"pets.collar"},
+			"select":   `SELECT shows.id,shows.name FROM "shows"`,
+			"search":   "pets.species = 'dog'",
+		},
+	}
+	for _, test := range tests {
+		args := &ListArguments{Fields: []string{"species", "name", "id"}, Preloads: test["preloads"].([]string)}
+		listCtx, model, serviceErr := genericService.newListContext(context.Background(), "", args, test["list"])
+		Expect(serviceErr).ToNot(HaveOccurred())
+		d := g.GetInstanceDao(context.Background(), model)
+		_, serviceErr = genericService.buildPreload(listCtx, &d)
+		Expect(serviceErr).ToNot(HaveOccurred())
+		_, serviceErr = genericService.buildSelect(listCtx, &d)
+		Expect(serviceErr).ToNot(HaveOccurred())
+
+		// sqlmock rejects the unexpected query, reporting the statement it was given
+		err := d.Fetch(0, 1, test["list"])
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(test["select"].(string)))
+
+		listCtx, model, serviceErr = genericService.newListContext(context.Background(), "", &ListArguments{Search: test["search"].(string)}, test["list"])
+		Expect(serviceErr).ToNot(HaveOccurred())
+		d = g.GetInstanceDao(context.Background(), model)
+		_, serviceErr = genericService.buildSearch(listCtx, &d)
+		Expect(serviceErr).To(HaveOccurred())
+		Expect(serviceErr.Code).To(Equal(errors.ErrorBadRequest))
+	}
+}
+
+func TestGetArguments(t *testing.T) {
+	RegisterTestingT(t)
+
+	args := NewGetArguments(url.Values{"fields": {"species, created_at"}, "include": {" owner,,owner.teams "}})
+	Expect(args.Fields).To(Equal([]string{"species", "created_at", "id"}))
+	Expect(args.Preloads).To(Equal([]string{"owner", "owner.teams"}))
+
+	args = NewGetArguments(url.Values{})
+	Expect(args.Fields).To(BeNil())
+	Expect(args.Preloads).To(BeNil())
+}
+
+func TestGetUnknownInclude(t *testing.T) {
+	RegisterTestingT(t)
+	var dbFactory db.SessionFactory = dbmocks.NewMockSessionFactory()
+	defer dbFactory.Close()
+
+	genericService := sqlGenericService{genericDao: dao.NewGenericDao(&dbFactory)}
+
+	var dino testModel
+	serviceErr := genericService.Get(context.Background(), "", "1", &GetArguments{Preloads: []string{"owner"}}, &dino)
+	Expect(serviceErr).To(HaveOccurred())
+	Expect(serviceErr.Code).To(Equal(errors.ErrorBadRequest))
+	Expect(serviceErr.Reason).To(ContainSubstring("owner is not a related resource"))
+}
+
+func TestRelatedSearchTranslation(t *testing.T) {
+	RegisterTestingT(t)
+	var dbFactory db.SessionFactory = dbmocks.NewMockSessionFactory()
+	defer dbFactory.Close()
+
+	g := dao.NewGenericDao(&dbFactory)
+	genericService := sqlGenericService{genericDao: g}
+
+	tests := []map[string]interface{}{
+		{
+			"search":  "owner.team.name = 'dino-lovers'",
+			"orderBy": []string{"owner.name desc", "species"},
+			"sql": []string{
+				`LEFT JOIN owners AS "owner" ON "owner".id = pets.owner_id AND "owner".deleted_at IS NULL`,
+				`LEFT JOIN teams AS "owner_team" ON "owner_team".id = "owner".team_id AND "owner_team".deleted_at IS NULL`,
+				`WHERE "owner_team".name = $1`,
+				`GROUP BY "owner".id,"owner_team".id,pets.id`,
+				`ORDER BY "owner".name desc,pets.species asc`,
+			},
+		},
+		{
+			"search":  "owner.pets.species = 'dog' and owner.team.name = 'dino-lovers'",
+			"orderBy": []string{},
+			"sql": []string{
+				`LEFT JOIN pets AS "owner_pets" ON "owner_pets".owner_id = "owner".id AND "owner_pets".deleted_at IS NULL`,
+				`("owner_pets".species = $1 AND "owner_team".name = $2)`,
+			},
+		},
+	}
+	for _, test := range tests {
+		var list []testPet
+		args := &ListArguments{Search: test["search"].(string), OrderBy: test["orderBy"].([]string)}
+		listCtx, model, serviceErr := genericService.newListContext(context.Background(), "", args, &list)
+		Expect(serviceErr).ToNot(HaveOccurred())
+		d := g.GetInstanceDao(context.Background(), model)
+		_, serviceErr = genericService.buildOrderBy(listCtx, &d)
+		Expect(serviceErr).ToNot(HaveOccurred())
+		_, serviceErr = genericService.buildSearch(listCtx, &d)
+		Expect(serviceErr).ToNot(HaveOccurred())
+
+		// sqlmock rejects the unexpected query, reporting the statement it was given
+		err := d.Fetch(0, 1, &list)
+		Expect(err).To(HaveOccurred())
+		for _, sql := range test["sql"].([]string) {
+			Expect(err.Error()).To(ContainSubstring(sql))
+		}
+	}
+
+	// unknown relations anywhere along the path are rejected
+	for _, args := range []*ListArguments{
+		{Search: "owner.club.name = 'x'"},
+		{OrderBy: []string{"owner.club.name"}},
+	} {
+		var list []testPet
+		listCtx, model, serviceErr := genericService.newListContext(context.Background(), "", args, &list)
+		Expect(serviceErr).ToNot(HaveOccurred())
+		d := g.GetInstanceDao(context.Background(), model)
+		_, serviceErr = genericService.buildOrderBy(listCtx, &d)
+		if serviceErr == nil {
+			_, serviceErr = genericService.buildSearch(listCtx, &d)
+		}
+		Expect(serviceErr).To(HaveOccurred())
+		Expect(serviceErr.Code).To(Equal(errors.ErrorBadRequest))
+		Expect(serviceErr.Reason).To(Equal("owner.club is not a related resource of testPet"))
+	}
+}
+
+func TestTextSearchTranslation(t *testing.T) {
+	RegisterTestingT(t)
+	var dbFactory db.SessionFactory = dbmocks.NewMockSessionFactory()
+	defer dbFactory.Close()
+
+	g := dao.NewGenericDao(&dbFactory)
+	genericService := sqlGenericService{genericDao: g}
+	RegisterTextSearchFields("testModel", "species")
+	defer delete(textSearchFields, "testModel")
+
+	tests := []map[string]interface{}{
+		{
+			"search": "species ~= 'big teeth' and not species ~= 'wings'",
+			"sql": []string{
+				`(to_tsvector('english', dinosaurs.species) @@ plainto_tsquery('english', $1) AND NOT (to_tsvector('english', dinosaurs.species) @@ plainto_tsquery('english', $2)))`,
+				`ORDER BY ts_rank(to_tsvector('english', dinosaurs.species), plainto_tsquery('english', 'big teeth')) desc`,
+			},
+		},
+		{
+			// quotes in the text must not break out of the relevance ordering
+			"search":  "species ~= 'o''neil'",
+			"orderBy": []string{},
+			"sql":     []string{`plainto_tsquery('english', 'o''neil')) desc`},
+		},
+		{
+			// an explicit order replaces the relevance
+			"search":  "species ~= 'teeth'",
+			"orderBy": []string{"created_at desc"},
+			"sql":     []string{`ORDER BY dinosaurs.created_at desc LIMIT`},
+		},
+	}
+	for _, test := range tests {
+		var list []testModel
+		args := &ListArguments{Search: test["search"].(string)}
+		if orderBy, ok := test["orderBy"]; ok {
+			args.OrderBy = orderBy.([]string)
+		}
+		listCtx, model, serviceErr := genericService.newListContext(context.Background(), "", args, &list)
+		Expect(serviceErr).ToNot(HaveOccurred())
+		d := g.GetInstanceDao(context.Background(), model)
+		_, serviceErr = genericService.buildOrderBy(listCtx, &d)
+		Expect(serviceErr).ToNot(HaveOccurred())
+		_, serviceErr = genericService.buildSearch(listCtx, &d)
+		Expect(serviceErr).ToNot(HaveOccurred())
+
+		// sqlmock rejects the unexpected query, reporting the statement it was given
+		err := d.Fetch(0, 1, &list)
+		Expect(err).To(HaveOccurred())
+		for _, sql := range test["sql"].([]string) {
+			Expect(err.Error()).To(ContainSubstring(sql))
+		}
+	}
+
+	// only registered columns support full-text search
+	var list []testModel
+	listCtx, model, serviceErr := genericService.newListContext(context.Background(), "", &ListArguments{Search: "created_at ~= 'teeth'"}, &list)
+	Expect(serviceErr).ToNot(HaveOccurred())
+	d := g.GetInstanceDao(context.Background(), model)
+	_, serviceErr = genericService.buildSearch(listCtx, &d)
+	Expect(serviceErr).To(HaveOccurred())
+	Expect(serviceErr.Code).To(Equal(errors.ErrorBadRequest))
+	Expect(serviceErr.Reason).To(Equal("dinosaurs.created_at does not support full-text search"))
+}
+
+func TestRelativeTimeTranslation(t *testing.T) {
+	RegisterTestingT(t)
+	var dbFactory db.SessionFactory = dbmocks.NewMockSessionFactory()
+	defer dbFactory.Close()
+
+	g := dao.NewGenericDao(&dbFactory)
+	now := time.Date(2024, 3, 10, 15, 30, 0, 0, time.UTC)
+	genericService := sqlGenericService{genericDao: g, clock: func() time.Time { return now }}
+
+	tests := []map[string]interface{}{
+		{
+			"search": "created_at > now-24h and updated_at < today",
+			"values": ConsistOf("2024-03-09T15:30:00Z", "2024-03-10T00:00:00Z"),
+		},
+		{
+			"search": "created_at between today-1w and now+1d-90m",
+			"values": ConsistOf("2024-03-03T00:00:00Z", "2024-03-11T14:00:00Z"),
+		},
+		{
+			// quoted relative times are taken too when compared with timestamps
+			"search": "created_at >= 'now-30s'",
+			"values": ConsistOf("2024-03-10T15:29:30Z"),
+		},
+		{
+			// but stay strings for any other column
+			"search": "species = 'today' or species = 'now_playing'",
+			"values": ConsistOf("today", "now_playing"),
+		},
+		{
+			// along relations, and without mistaking fields for relative times
+			"search": "owner.created_at > today-1d and owner.name = 'known.today'",
+			"values": ConsistOf("2024-03-09T00:00:00Z", "known.today"),
+		},
+	}
+	for _, test := range tests {
+		var list []testPet
+		listCtx, model, serviceErr := genericService.newListContext(context.Background(), "", &ListArguments{Search: test["search"].(string)}, &list)
+		Expect(serviceErr).ToNot(HaveOccurred())
+		d := g.GetInstanceDao(context.Background(), model)
+		_, values, serviceErr := genericService.buildSearchValues(listCtx, &d)
+		Expect(serviceErr).ToNot(HaveOccurred(), test["search"])
+		Expect(values).To(test["values"].(types.GomegaMatcher), test["search"])
+	}
+
+	// relative times apply to timestamp columns only
+	tests = []map[string]interface{}{
+		{
+			"search": "species > now-1h",
+			"error":  "pets.species is not a timestamp field, it cannot be compared with now-1h",
+		},
+		{
+			"search": "owner.name < today",
+			"error":  `"owner".name is not a timestamp field, it cannot be compared with today`,
+		},
+		{
+			"search": "created_at > now-99999999999d",
+			"error":  "now-99999999999d is out of range",
+		},
+	}
+	for _, test := range tests {
+		var list []testPet
+		listCtx, model, serviceErr := genericService.newListContext(context.Background(), "", &ListArguments{Search: test["search"].(string)}, &list)
+		Expect(serviceErr).ToNot(HaveOccurred())
+		d := g.GetInstanceDao(context.Background(), model)
+		_, _, serviceErr = genericService.buildSearchValues(listCtx, &d)
+		Expect(serviceErr).To(HaveOccurred())
+		Expect(serviceErr.Code).To(Equal(errors.ErrorBadRequest))
+		Expect(serviceErr.Reason).To(Equal(test["error"].(string)))
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	RegisterTestingT(t)
+	mockFactory := dbmocks.NewMockSessionFactory()
+	var dbFactory db.SessionFactory = mockFactory
+	defer dbFactory.Close()
+
+	genericService := sqlGenericService{genericDao: dao.NewGenericDao(&dbFactory)}
+
+	created := time.Date(2024, 3, 10, 15, 30, 0, 0, time.UTC)
+	mockFactory.Mock().ExpectQuery(regexp.QuoteMeta(
+		`SELECT COUNT(DISTINCT dinosaurs.id) AS count, dinosaurs.species AS group_0, MIN(dinosaurs.created_at) AS min_0 ` +
+			`FROM "dinosaurs" WHERE dinosaurs.species <> $1 AND "dinosaurs"."deleted_at" IS NULL ` +
+			`GROUP BY "dinosaurs"."species" ORDER BY dinosaurs.species`)).
+		WithArgs("raptor").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "group_0", "min_0"}).
+			AddRow(int64(2), "rex", created).
+			AddRow(int64(1), "stego", created))
+
+	args := &AggregateArguments{Search: "species <> 'raptor'", GroupBy: []string{"species"}, Min: []string{"created_at"}}
+	aggregations, serviceErr := genericService.Aggregate(context.Background(), "", args, &testModel{})
+	Expect(serviceErr).ToNot(HaveOccurred())
+	Expect(mockFactory.Mock().ExpectationsWereMet()).To(Succeed())
+	Expect(aggregations).To(HaveLen(2))
+	Expect(aggregations[0].Group).To(Equal(map[string]interface{}{"species": "rex"}))
+	Expect(aggregations[0].Count).To(Equal(int64(2)))
+	Expect(aggregations[0].Min).To(Equal(map[string]interface{}{"created_at": created}))
+	Expect(aggregations[0].Max).To(BeEmpty())
+	Expect(aggregations[1].Group["species"]).To(Equal("stego"))
+
+	// the fields are checked the same way as in searches
+	SearchDisallowedFields["testModel"] = map[string]string{"species": "species"}
+	defer delete(SearchDisallowedFields, "testModel")
+	for _, args := range []*AggregateArguments{
+		{GroupBy: []string{"species"}},
+		{Max: []string{"weight"}},
+	} {
+		_, serviceErr = genericService.Aggregate(context.Background(), "", args, &testModel{})
+		Expect(serviceErr).To(HaveOccurred())
+		Expect(serviceErr.Code).To(Equal(errors.ErrorBadRequest))
+		Expect(serviceErr.Reason).To(HaveSuffix("is not a valid field name"))
+	}
+}
+
+func TestAggregateArguments(t *testing.T) {
+	RegisterTestingT(t)
+
+	params := url.Values{}
+	params.Set("search", " species like 'r%' ")
+	params.Set("group_by", "species, created_at")
+	params.Set("max", "updated_at,")
+	args := NewAggregateArguments(params)
+	Expect(args.Search).To(Equal("species like 'r%'"))
+	Expect(args.GroupBy).To(Equal([]string{"species", "created_at"}))
+	Expect(args.Min).To(BeEmpty())
+	Expect(args.Max).To(Equal([]string{"updated_at"}))
+}
+
+func TestLabelSelectorTranslation(t *testing.T) {
+	RegisterTestingT(t)
+	var dbFactory db.SessionFactory = dbmocks.NewMockSessionFactory()
+	defer dbFactory.Close()
+
+	g := dao.NewGenericDao(&dbFactory)
+	genericService := sqlGenericService{genericDao: g}
+
+	tests := []map[string]interface{}{
+		{
+			"selector": "env=prod",
+			"sql":      `(dinosaurs.labels @> $1::jsonb)`,
+			"values":   []string{`{"env":"prod"}`},
+		},
+		{
+			"selector": "example.com/tier in (web, api),env!=dev",
+			"sql":      `((dinosaurs.labels @> $1::jsonb OR dinosaurs.labels @> $2::jsonb) AND NOT (dinosaurs.labels @> $3::jsonb))`,
+			"values":   []string{`{"example.com/tier":"web"}`, `{"example.com/tier":"api"}`, `{"env":"dev"}`},
+		},
+		{
+			"selector": "env==prod, tier notin (db), canary, !legacy",
+			"sql": `(dinosaurs.labels @> $1::jsonb AND NOT (dinosaurs.labels @> $2::jsonb) AND ` +
+				`dinosaurs.labels ->> $3 IS NOT NULL AND dinosaurs.labels ->> $4 IS NULL)`,
+			"values": []string{`{"env":"prod"}`, `{"tier":"db"}`, "canary", "legacy"},
+		},
+	}
+	for _, test := range tests {
+		var list []testModel
+		selector := test["selector"].(string)
+		listCtx, model, serviceErr := genericService.newListContext(context.Background(), "", &ListArguments{LabelSelector: selector}, &list)
+		Expect(serviceErr).ToNot(HaveOccurred())
+		d := g.GetInstanceDao(context.Background(), model)
+		_, serviceErr = genericService.buildLabelSelector(listCtx, &d)
+		Expect(serviceErr).ToNot(HaveOccurred(), selector)
+
+		// sqlmock rejects the unexpected query, reporting the statement and the values it was given
+		err := d.Fetch(0, 1, &list)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(test["sql"].(string)), selector)
+		for _, value := range test["values"].([]string) {
+			Expect(err.Error()).To(ContainSubstring(value), selector)
+		}
+	}
+
+	for selector, reason := range map[string]string{
+		"env in (prod":                   "unbalanced parentheses",
+		"env=prod,,tier":                 "empty requirement",
+		"env in ()":                      "must be given values",
+		"env=prod value":                 "env=prod value",
+		"-env=prod":                      `label key "-env"`,
+		"env=not allowed!":               "env=not allowed!",
+		"env=" + strings.Repeat("x", 64): "label value",
+	} {
+		var list []testModel
+		listCtx, model, serviceErr := genericService.newListContext(context.Background(), "", &ListArguments{LabelSelector: selector}, &list)
+		Expect(serviceErr).ToNot(HaveOccurred())
+		d := g.GetInstanceDao(context.Background(), model)
+		_, serviceErr = genericService.buildLabelSelector(listCtx, &d)
+		Expect(serviceErr).To(HaveOccurred(), selector)
+		Expect(serviceErr.Code).To(Equal(errors.ErrorBadRequest))
+		Expect(serviceErr.Reason).To(ContainSubstring(reason), selector)
+	}
+
+	// only the kinds embedding api.Labeled can be selected by label
+	var pets []testPet
+	listCtx, model, serviceErr := genericService.newListContext(context.Background(), "", &ListArguments{LabelSelector: "env=prod"}, &pets)
+	Expect(serviceErr).ToNot(HaveOccurred())
+	d := g.GetInstanceDao(context.Background(), model)
+	_, serviceErr = genericService.buildLabelSelector(listCtx, &d)
+	Expect(serviceErr).To(HaveOccurred())
+	Expect(serviceErr.Reason).To(Equal("testPet has no labels to select"))
+}
+
+func TestFieldAllowlist(t *testing.T) {
+	RegisterTestingT(t)
+	var dbFactory db.SessionFactory = dbmocks.NewMockSessionFactory()
+	defer dbFactory.Close()
+
+	g := dao.NewGenericDao(&dbFactory)
+	genericService := sqlGenericService{genericDao: g}
+
+	RegisterFieldAllowlist("testModel", FieldAllowlist{
+		Searchable:  []string{"species", "created_at"},
+		Sortable:    []string{"created_at"},
+		Projectable: []string{"species"},
+	})
+	defer delete(fieldAllowlists, "testModel")
+
+	// the allowed fields go through
+	var list []testModel
+	listCtx, model, serviceErr := genericService.newListContext(context.Background(), "",
+		&ListArguments{Search: "species = 'rex' and created_at > now-1d", OrderBy: []string{"created_at desc"}, Fields: []string{"id", "species"}}, &list)
+	Expect(serviceErr).ToNot(HaveOccurred())
+	d := g.GetInstanceDao(context.Background(), model)
+	for _, builderFn := range []listBuilder{genericService.buildSelect, genericService.buildOrderBy, genericService.buildSearch} {
+		_, serviceErr = builderFn(listCtx, &d)
+		Expect(serviceErr).ToNot(HaveOccurred())
+	}
+
+	// every other field is denied as a search the kind cannot run
+	tests := []struct {
+		args   *ListArguments
+		reason string
+	}{
+		{
+			args:   &ListArguments{Search: "species = 'rex' or updated_at > now-1d"},
+			reason: "Failed to parse search query: updated_at is not a searchable field, the searchable fields are: created_at, species",
+		},
+		{
+			args:   &ListArguments{OrderBy: []string{"species asc"}},
+			reason: "Failed to parse search query: species is not a sortable field, the sortable fields are: created_at",
+		},
+		{
+			args:   &ListArguments{Fields: []string{"id", "labels"}},
+			reason: "Failed to parse search query: labels is not a projectable field, the projectable fields are: species",
+		},
+	}
+	for _, test := range tests {
+		listCtx, model, serviceErr := genericService.newListContext(context.Background(), "", test.args, &list)
+		Expect(serviceErr).ToNot(HaveOccurred())
+		d := g.GetInstanceDao(context.Background(), model)
+		for _, builderFn := range []listBuilder{genericService.buildSelect, genericService.buildOrderBy, genericService.buildSearch} {
+			if _, serviceErr = builderFn(listCtx, &d); serviceErr != nil {
+				break
+			}
+		}
+		Expect(serviceErr).To(HaveOccurred())
+		Expect(serviceErr.Code).To(Equal(errors.ErrorFailedToParseSearch))
+		Expect(serviceErr.Reason).To(Equal(test.reason))
+	}
+
+	// aggregations group on searchable fields only
+	_, serviceErr = genericService.Aggregate(context.Background(), "", &AggregateArguments{GroupBy: []string{"updated_at"}}, &testModel{})
+	Expect(serviceErr).To(HaveOccurred())
+	Expect(serviceErr.Code).To(Equal(errors.ErrorFailedToParseSearch))
+}
+
+func TestDeletedListing(t *testing.T) {
+	RegisterTestingT(t)
+	var dbFactory db.SessionFactory = dbmocks.NewMockSessionFactory()
+	defer dbFactory.Close()
+
+	g := dao.NewGenericDao(&dbFactory)
+	genericService := sqlGenericService{genericDao: g}
+
+	auth.SetAdmins([]string{"admin"})
+	defer auth.SetAdmins(nil)
+
+	// only the admins list the deleted resources
+	for _, username := range []string{"", "user"} {
+		var list []testModel
+		ctx := auth.SetUsernameContext(context.Background(), username)
+		listCtx, model, serviceErr := genericService.newListContext(ctx, username, &ListArguments{IncludeDeleted: true}, &list)
+		Expect(serviceErr).ToNot(HaveOccurred())
+		d := g.GetInstanceDao(ctx, model)
+		_, serviceErr = genericService.buildDeleted(listCtx, &d)
+		Expect(serviceErr).To(HaveOccurred())
+		Expect(serviceErr.Code).To(Equal(errors.ErrorForbidden))
+		Expect(serviceErr.Reason).To(Equal("Only admins can list deleted resources"))
+	}
+
+	tests := []struct {
+		args     *ListArguments
+		contains string
+		excludes string
+	}{
+		{
+			args:     &ListArguments{},
+			contains: `"dinosaurs"."deleted_at" IS NULL`,
+		},
+		{
+			args:     &ListArguments{IncludeDeleted: true},
+			excludes: "deleted_at",
+		},
+		{
+			args:     &ListArguments{OnlyDeleted: true},
+			contains: "dinosaurs.deleted_at IS NOT NULL",
+			excludes: `"dinosaurs"."deleted_at" IS NULL`,
+		},
+	}
+	ctx := auth.SetUsernameContext(context.Background(), "admin")
+	for _, test := range tests {
+		var list []testModel
+		listCtx, model, serviceErr := genericService.newListContext(ctx, "admin", test.args, &list)
+		Expect(serviceErr).ToNot(HaveOccurred())
+		d := g.GetInstanceDao(ctx, model)
+		_, serviceErr = genericService.buildDeleted(listCtx, &d)
+		Expect(serviceErr).ToNot(HaveOccurred())
+
+		// sqlmock rejects the unexpected query, reporting the statement
+		err := d.Fetch(0, 1, &list)
+		Expect(err).To(HaveOccurred())
+		if test.contains != "" {
+			Expect(err.Error()).To(ContainSubstring(test.contains))
+		}
+		if test.excludes != "" {
+			Expect(err.Error()).ToNot(ContainSubstring(test.excludes))
+		}
+	}
+}
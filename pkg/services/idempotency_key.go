@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+)
+
+// IdempotentRequest runs a request, returning the status code and body of its response
+type IdempotentRequest func() (int, []byte, *errors.ServiceError)
+
+type IdempotencyKeyService interface {
+	// Run runs the request once per key: the retries sent with the key before it expires get the stored response
+	// of the first request, replayed is true then. Only the successful responses are stored.
+	Run(ctx context.Context, request *api.IdempotencyKey, run IdempotentRequest) (response *api.IdempotencyKey, replayed bool, err *errors.ServiceError)
+	DeleteExpired(ctx context.Context, now time.Time) (int64, *errors.ServiceError)
+}
+
+func NewIdempotencyKeyService(lockFactory db.LockFactory, idempotencyKeyDao dao.IdempotencyKeyDao, ttl time.Duration) IdempotencyKeyService {
+	return &sqlIdempotencyKeyService{
+		lockFactory:       lockFactory,
+		idempotencyKeyDao: idempotencyKeyDao,
+		ttl:               ttl,
+	}
+}
+
+var _ IdempotencyKeyService = &sqlIdempotencyKeyService{}
+
+type sqlIdempotencyKeyService struct {
+	lockFactory       db.LockFactory
+	idempotencyKeyDao dao.IdempotencyKeyDao
+	ttl               time.Duration
+}
+
+func (s *sqlIdempotencyKeyService) Run(ctx context.Context, request *api.IdempotencyKey, run IdempotentRequest) (*api.IdempotencyKey, bool, *errors.ServiceError) {
+	// a retry sent while the first request still runs waits for its response
+	lockID := fmt.Sprintf("%s %s %s %s", request.Username, request.Method, request.Path, request.Key)
+	lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, lockID, db.IdempotencyKeys)
+	if err != nil {
+		return nil, false, errors.DatabaseAdvisoryLock(err).WithStack()
+	}
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+
+	stored, err := s.idempotencyKeyDao.Get(ctx, request, time.Now())
+	if err == nil {
+		if stored.RequestHash != request.RequestHash {
+			return nil, false, errors.IdempotencyKeyReused("Idempotency key '%s' was already used with a different request body", request.Key)
+		}
+		return stored, true, nil
+	}
+	if !e.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, errors.GeneralError("Unable to get idempotency key").WithCause(err).WithStack()
+	}
+
+	statusCode, response, serviceErr := run()
+	if serviceErr != nil {
+		return nil, false, serviceErr
+	}
+	request.StatusCode = statusCode
+	request.Response = response
+	request.ExpiresAt = time.Now().Add(s.ttl)
+	if _, err := s.idempotencyKeyDao.Create(ctx, request); err != nil {
+		// the request succeeded, only its retries run it again
+		logger.NewOCMLogger(ctx).Error(fmt.Sprintf("Unable to store the response of idempotency key '%s': %s", request.Key, err))
+	}
+	return request, false, nil
+}
+
+// DeleteExpired permanently deletes the keys expired before now
+func (s *sqlIdempotencyKeyService) DeleteExpired(ctx context.Context, now time.Time) (int64, *errors.ServiceError) {
+	deleted, err := s.idempotencyKeyDao.DeleteExpired(ctx, now)
+	if err != nil {
+		return 0, errors.GeneralError("Unable to delete expired idempotency keys").WithCause(err).WithStack()
+	}
+	return deleted, nil
+}
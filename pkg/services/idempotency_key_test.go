@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	daomocks "github.com/openshift-online/rh-trex-ai/pkg/dao/mocks"
+	dbmocks "github.com/openshift-online/rh-trex-ai/pkg/db/mocks"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+func TestIdempotencyKeyRun(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := context.Background()
+	service := NewIdempotencyKeyService(dbmocks.NewMockAdvisoryLockFactory(), daomocks.NewIdempotencyKeyDao(), time.Hour)
+	newRequest := func(hash string) *api.IdempotencyKey {
+		return &api.IdempotencyKey{Key: "key", Username: "alice", Method: http.MethodPost, Path: "/dinosaurs", RequestHash: hash}
+	}
+
+	runs := 0
+	run := func() (int, []byte, *errors.ServiceError) {
+		runs++
+		return http.StatusCreated, []byte(`{"id":"1"}`), nil
+	}
+
+	response, replayed, err := service.Run(ctx, newRequest("a"), run)
+	Expect(err).To(BeNil())
+	Expect(replayed).To(BeFalse())
+	Expect(response.StatusCode).To(Equal(http.StatusCreated))
+	Expect(runs).To(Equal(1))
+
+	// the retry gets the stored response
+	response, replayed, err = service.Run(ctx, newRequest("a"), run)
+	Expect(err).To(BeNil())
+	Expect(replayed).To(BeTrue())
+	Expect(string(response.Response)).To(Equal(`{"id":"1"}`))
+	Expect(runs).To(Equal(1))
+
+	// the key cannot be reused for another body
+	_, _, err = service.Run(ctx, newRequest("b"), run)
+	Expect(err).NotTo(BeNil())
+	Expect(err.Code).To(Equal(errors.ErrorIdempotencyKeyReused))
+	Expect(err.HttpCode).To(Equal(http.StatusUnprocessableEntity))
+
+	// the key belongs to the user who sent it
+	other := newRequest("b")
+	other.Username = "bob"
+	_, replayed, err = service.Run(ctx, other, run)
+	Expect(err).To(BeNil())
+	Expect(replayed).To(BeFalse())
+	Expect(runs).To(Equal(2))
+
+	// failed requests are not stored, their retries run again
+	failing := newRequest("c")
+	failing.Key = "failing"
+	_, _, err = service.Run(ctx, failing, func() (int, []byte, *errors.ServiceError) {
+		return 0, nil, errors.Validation("invalid")
+	})
+	Expect(err.Code).To(Equal(errors.ErrorValidation))
+	_, replayed, err = service.Run(ctx, failing, run)
+	Expect(err).To(BeNil())
+	Expect(replayed).To(BeFalse())
+	Expect(runs).To(Equal(3))
+
+	deleted, err := service.DeleteExpired(ctx, time.Now().Add(2*time.Hour))
+	Expect(err).To(BeNil())
+	Expect(deleted).To(Equal(int64(3)))
+}
+
+// failingIdempotencyKeyDao fails every call with err
+type failingIdempotencyKeyDao struct {
+	dao.IdempotencyKeyDao
+	err error
+}
+
+func (d *failingIdempotencyKeyDao) Get(ctx context.Context, request *api.IdempotencyKey, now time.Time) (*api.IdempotencyKey, error) {
+	return nil, d.err
+}
+
+func (d *failingIdempotencyKeyDao) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	return 0, d.err
+}
+
+func TestIdempotencyKeyDBErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := context.Background()
+	cause := fmt.Errorf("connection refused")
+	service := NewIdempotencyKeyService(dbmocks.NewMockAdvisoryLockFactory(), &failingIdempotencyKeyDao{err: cause}, time.Hour)
+
+	// the database errors are logged, not sent to the clients
+	_, _, err := service.Run(ctx, &api.IdempotencyKey{Key: "key"}, nil)
+	Expect(err.Reason).To(Equal("Unable to get idempotency key"))
+	Expect(e.Is(err, cause)).To(BeTrue())
+
+	_, err = service.DeleteExpired(ctx, time.Now())
+	Expect(err.Reason).To(Equal("Unable to delete expired idempotency keys"))
+	Expect(e.Is(err, cause)).To(BeTrue())
+}
@@ -9,14 +9,35 @@ import (
 // ListArguments are arguments relevant for listing objects.
 // This struct is common to all service List funcs in this package
 type ListArguments struct {
-	Page     int
-	Size     int64
+	Page          int
+	Size          int64
+	Preloads      []string
+	Search        string
+	LabelSelector string
+	OrderBy       []string
+	Fields        []string
+	// IncludeDeleted lists the soft-deleted objects too, OnlyDeleted lists them alone. Both are for admins.
+	IncludeDeleted bool
+	OnlyDeleted    bool
+}
+
+// GetArguments are arguments relevant for fetching a single object.
+// They accept the same "fields" and "include" parameters as ListArguments
+type GetArguments struct {
 	Preloads []string
-	Search   string
-	OrderBy  []string
 	Fields   []string
 }
 
+// AggregateArguments are arguments relevant for aggregating objects.
+// The objects matching the search are grouped by the GroupBy fields, each group is
+// counted and reports the smallest values of the Min fields and the largest of the Max fields
+type AggregateArguments struct {
+	Search  string
+	GroupBy []string
+	Min     []string
+	Max     []string
+}
+
 // ~65500 is the maximum number of parameters that can be provided to a postgres WHERE IN clause
 // Use it as a sane max
 const MaxListSize = 65500
@@ -45,23 +66,66 @@ func NewListArguments(params url.Values) *ListArguments {
 	if v := strings.Trim(params.Get("orderBy"), " "); v != "" {
 		listArgs.OrderBy = strings.Split(v, ",")
 	}
+	listArgs.LabelSelector = strings.Trim(params.Get("labelSelector"), " ")
+	listArgs.IncludeDeleted, _ = strconv.ParseBool(strings.Trim(params.Get("include_deleted"), " "))
+	listArgs.OnlyDeleted, _ = strconv.ParseBool(strings.Trim(params.Get("only_deleted"), " "))
+	listArgs.Preloads = listArgument(params, "include")
+	listArgs.Fields = fieldsArgument(params)
+
+	return listArgs
+}
+
+// NewGetArguments Create GetArguments from url query parameters
+func NewGetArguments(params url.Values) *GetArguments {
+	return &GetArguments{
+		Preloads: listArgument(params, "include"),
+		Fields:   fieldsArgument(params),
+	}
+}
+
+// NewAggregateArguments Create AggregateArguments from url query parameters
+func NewAggregateArguments(params url.Values) *AggregateArguments {
+	return &AggregateArguments{
+		Search:  strings.Trim(params.Get("search"), " "),
+		GroupBy: listArgument(params, "group_by"),
+		Min:     listArgument(params, "min"),
+		Max:     listArgument(params, "max"),
+	}
+}
+
+// listArgument parses a comma-separated list parameter, e.g. the related resources to include
+func listArgument(params url.Values, name string) []string {
+	var values []string
+	if v := strings.Trim(params.Get(name), " "); v != "" {
+		for _, value := range strings.Split(v, ",") {
+			value = strings.Trim(value, " ")
+			if value == "" { // skip leading/trailing commas and spaces
+				continue
+			}
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// fieldsArgument parses the comma-separated list of fields to return, "id" is always returned
+func fieldsArgument(params url.Values) []string {
+	var fields []string
 	if v := strings.Trim(params.Get("fields"), " "); v != "" {
-		fields := strings.Split(v, ",")
 		idNotPresent := true
-		for i := 0; i < len(fields); i++ {
-			field := strings.Trim(fields[i], " ")
+		for _, field := range strings.Split(v, ",") {
+			field = strings.Trim(field, " ")
 			if field == "" { // skip leading/trailing commas and spaces
 				continue
 			}
 			if field == "id" {
 				idNotPresent = false
 			}
-			listArgs.Fields = append(listArgs.Fields, field)
+			fields = append(fields, field)
 		}
 		if idNotPresent {
-			listArgs.Fields = append(listArgs.Fields, "id")
+			fields = append(fields, "id")
 		}
 	}
-
-	return listArgs
+	return fields
 }
@@ -2,6 +2,7 @@ package services
 
 import (
 	e "errors"
+	"fmt"
 	"strings"
 
 	"gorm.io/gorm"
@@ -29,23 +30,37 @@ func HandleGetError(resourceType, field string, value interface{}, err error) *e
 	if e.Is(err, gorm.ErrRecordNotFound) {
 		return errors.NotFound("%s with %s='%v' not found", resourceType, field, value)
 	}
-	return errors.GeneralError("Unable to find %s with %s='%v': %s", resourceType, field, value, err)
+	if serviceErr := handleDBError(resourceType, err, fmt.Sprintf("%s with %s='%v' conflicts with existing records", resourceType, field, value)); serviceErr != nil {
+		return serviceErr
+	}
+	return errors.GeneralError("Unable to find %s with %s='%v'", resourceType, field, value).WithCause(err).WithStack()
 }
 
 func HandleCreateError(resourceType string, err error) *errors.ServiceError {
+	reason := fmt.Sprintf("This %s already exists", resourceType)
+	if serviceErr := handleDBError(resourceType, err, reason); serviceErr != nil {
+		return serviceErr
+	}
 	if strings.Contains(err.Error(), "violates unique constraint") {
-		return errors.Conflict("This %s already exists", resourceType)
+		return errors.Conflict("%s", reason).WithCause(err)
 	}
-	return errors.GeneralError("Unable to create %s: %s", resourceType, err.Error())
+	return errors.GeneralError("Unable to create %s", resourceType).WithCause(err).WithStack()
 }
 
 func HandleUpdateError(resourceType string, err error) *errors.ServiceError {
+	reason := fmt.Sprintf("Changes to %s conflict with existing records", resourceType)
+	if serviceErr := handleDBError(resourceType, err, reason); serviceErr != nil {
+		return serviceErr
+	}
 	if strings.Contains(err.Error(), "violates unique constraint") {
-		return errors.Conflict("Changes to %s conflict with existing records", resourceType)
+		return errors.Conflict("%s", reason).WithCause(err)
 	}
-	return errors.GeneralError("Unable to update %s: %s", resourceType, err.Error())
+	return errors.GeneralError("Unable to update %s", resourceType).WithCause(err).WithStack()
 }
 
 func HandleDeleteError(resourceType string, err error) *errors.ServiceError {
-	return errors.GeneralError("Unable to delete %s: %s", resourceType, err.Error())
+	if serviceErr := handleDBError(resourceType, err, fmt.Sprintf("%s conflicts with existing records", resourceType)); serviceErr != nil {
+		return serviceErr
+	}
+	return errors.GeneralError("Unable to delete %s", resourceType).WithCause(err).WithStack()
 }
@@ -0,0 +1,46 @@
+package services
+
+import (
+	e "errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+func TestHandleCreateErrorUniqueViolation(t *testing.T) {
+	RegisterTestingT(t)
+
+	err := HandleCreateError("Dinosaur", fmt.Errorf("create: %w", &pgconn.PgError{
+		Code:    "23505",
+		Message: `duplicate key value violates unique constraint "idx_dinosaurs_species_era"`,
+		Detail:  "Key (species, era)=(Diplodocus, jurassic) already exists.",
+	}))
+	Expect(err.Code).To(Equal(errors.ErrorConflict))
+	Expect(err.Reason).To(Equal("This Dinosaur already exists"))
+	Expect(err.Details).To(Equal([]errors.FieldViolation{
+		{Field: "species", Rule: "unique", Message: "species must be unique"},
+		{Field: "era", Rule: "unique", Message: "era must be unique"},
+	}))
+
+	// the violations whose detail isn't reported have no field
+	err = HandleUpdateError("Dinosaur", fmt.Errorf(`duplicate key value violates unique constraint "idx_dinosaurs_species"`))
+	Expect(err.Code).To(Equal(errors.ErrorConflict))
+	Expect(err.Details).To(BeEmpty())
+}
+
+func TestHandleCreateErrorCause(t *testing.T) {
+	RegisterTestingT(t)
+
+	cause := fmt.Errorf("connection refused")
+	err := HandleCreateError("Dinosaur", cause)
+	Expect(err.Code).To(Equal(errors.ErrorGeneral))
+	// the cause is logged, not sent to the clients
+	Expect(err.Reason).To(Equal("Unable to create Dinosaur"))
+	Expect(err.Error()).To(HaveSuffix(": connection refused"))
+	Expect(e.Is(err, cause)).To(BeTrue())
+	Expect(err.StackTrace()).NotTo(BeEmpty())
+}
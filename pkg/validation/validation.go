@@ -0,0 +1,262 @@
+// Package validation checks the request bodies against the constraints declared by their
+// `validate` struct tags, e.g.
+//
+//	Species string `json:"species" validate:"required,max=255"`
+//
+// The openapi models get their tags from the x-go-custom-tag extension of their schema properties.
+// The rules of a tag are separated by commas:
+//
+//	required     the field must be set, and neither an empty string, list nor map
+//	min=N, max=N the bounds of a number, or of the length of a string, list or map
+//	enum=a|b|c   the string must be one of the values
+//	pattern=re   the string must match the regular expression, it must be the last rule of the tag
+//
+// The fields of nested structs, and the structs of lists and maps, are checked too.
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// TagName is the struct tag holding the rules of a field
+const TagName = "validate"
+
+// Violation is a constraint a field doesn't satisfy, the field is the path of its JSON name, e.g. items[0].name,
+// and the rule the name of the constraint, e.g. max
+type Violation struct {
+	Field   string
+	Rule    string
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s %s", v.Field, v.Message)
+}
+
+// Violations are all the constraints a value doesn't satisfy
+type Violations []Violation
+
+func (v Violations) Error() string {
+	messages := make([]string, len(v))
+	for i, violation := range v {
+		messages[i] = violation.String()
+	}
+	return strings.Join(messages, ", ")
+}
+
+// Struct checks the struct, or pointer to one, against the rules of its tags and returns all the violations found,
+// it panics when a tag is invalid as this is a programming error
+func Struct(i interface{}) Violations {
+	var violations Violations
+	value := reflect.ValueOf(i)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	checkStruct(value, "", &violations)
+	return violations
+}
+
+func checkStruct(value reflect.Value, path string, violations *Violations) {
+	if value.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		fieldPath := fieldName(field)
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+		checkField(value.Field(i), fieldPath, rulesOf(field), violations)
+	}
+}
+
+func checkField(value reflect.Value, path string, rules []rule, violations *Violations) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			for _, r := range rules {
+				if r.name == "required" {
+					*violations = append(*violations, Violation{Field: path, Rule: "required", Message: "is required"})
+				}
+			}
+			return
+		}
+		value = value.Elem()
+	}
+
+	for _, r := range rules {
+		if message := r.check(value); message != "" {
+			*violations = append(*violations, Violation{Field: path, Rule: r.name, Message: message})
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		checkStruct(value, path, violations)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			checkField(value.Index(i), fmt.Sprintf("%s[%d]", path, i), nil, violations)
+		}
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			checkField(value.MapIndex(key), fmt.Sprintf("%s.%v", path, key.Interface()), nil, violations)
+		}
+	}
+}
+
+// fieldName is the JSON name of the field, which is the one the clients know
+func fieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+type rule struct {
+	name     string
+	number   float64
+	values   []string
+	regexp   *regexp.Regexp
+	argument string
+}
+
+var rules sync.Map
+
+// rulesOf parses the tag of the field once, the rules are cached by tag
+func rulesOf(field reflect.StructField) []rule {
+	tag, ok := field.Tag.Lookup(TagName)
+	if !ok || tag == "" {
+		return nil
+	}
+	if cached, ok := rules.Load(tag); ok {
+		return cached.([]rule)
+	}
+	parsed, err := parseRules(tag)
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s tag of field %s: %s", TagName, field.Name, err))
+	}
+	rules.Store(tag, parsed)
+	return parsed
+}
+
+func parseRules(tag string) ([]rule, error) {
+	var parsed []rule
+	for tag != "" {
+		var item string
+		if strings.HasPrefix(tag, "pattern=") {
+			item, tag = tag, ""
+		} else if i := strings.Index(tag, ","); i >= 0 {
+			item, tag = tag[:i], tag[i+1:]
+		} else {
+			item, tag = tag, ""
+		}
+
+		name, argument, _ := strings.Cut(strings.TrimSpace(item), "=")
+		r := rule{name: name, argument: argument}
+		switch name {
+		case "required":
+		case "min", "max":
+			number, err := strconv.ParseFloat(argument, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be a number, not '%s'", name, argument)
+			}
+			r.number = number
+		case "enum":
+			if argument == "" {
+				return nil, fmt.Errorf("enum must list the values allowed")
+			}
+			r.values = strings.Split(argument, "|")
+		case "pattern":
+			re, err := regexp.Compile(argument)
+			if err != nil {
+				return nil, fmt.Errorf("pattern '%s' is invalid: %s", argument, err)
+			}
+			r.regexp = re
+		default:
+			return nil, fmt.Errorf("unknown rule '%s'", name)
+		}
+		parsed = append(parsed, r)
+	}
+	return parsed, nil
+}
+
+// check returns why the value doesn't satisfy the rule, or an empty string when it does
+func (r rule) check(value reflect.Value) string {
+	switch r.name {
+	case "required":
+		switch value.Kind() {
+		case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+			if value.Len() == 0 {
+				return "is required"
+			}
+		}
+	case "min", "max":
+		if n, ok := number(value); ok {
+			if r.name == "min" && n < r.number {
+				return fmt.Sprintf("must be at least %s", r.argument)
+			}
+			if r.name == "max" && n > r.number {
+				return fmt.Sprintf("must be at most %s", r.argument)
+			}
+			return ""
+		}
+		if n, ok := length(value); ok {
+			if r.name == "min" && float64(n) < r.number {
+				return fmt.Sprintf("must be at least %s long", r.argument)
+			}
+			if r.name == "max" && float64(n) > r.number {
+				return fmt.Sprintf("must be at most %s long", r.argument)
+			}
+		}
+	case "enum":
+		if value.Kind() != reflect.String {
+			return ""
+		}
+		for _, allowed := range r.values {
+			if value.String() == allowed {
+				return ""
+			}
+		}
+		return fmt.Sprintf("must be one of %s, not '%s'", strings.Join(r.values, ", "), value.String())
+	case "pattern":
+		if value.Kind() == reflect.String && !r.regexp.MatchString(value.String()) {
+			return fmt.Sprintf("must match %s", r.argument)
+		}
+	}
+	return ""
+}
+
+func number(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	}
+	return 0, false
+}
+
+// length counts the characters of the strings, not their bytes
+func length(value reflect.Value) (int, bool) {
+	switch value.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(value.String()), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return value.Len(), true
+	}
+	return 0, false
+}
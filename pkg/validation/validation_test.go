@@ -0,0 +1,94 @@
+package validation
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type engine struct {
+	Kind string `json:"kind" validate:"enum=diesel|electric"`
+	Hp   *int   `json:"hp,omitempty" validate:"required,min=1,max=1000"`
+}
+
+type truck struct {
+	Name    string            `json:"name" validate:"required,max=8"`
+	Plate   *string           `json:"plate,omitempty" validate:"pattern=^[A-Z]{2}-[0-9]{1,3}$"`
+	Engine  engine            `json:"engine"`
+	Trailer *engine           `json:"trailer,omitempty"`
+	Axles   []engine          `json:"axles" validate:"min=1"`
+	Spares  map[string]engine `json:"spares,omitempty"`
+	Notes   string
+	ignored string `validate:"required"`
+}
+
+func TestStruct(t *testing.T) {
+	RegisterTestingT(t)
+
+	hp := 300
+	plate := "AB-12"
+	valid := func() truck {
+		return truck{
+			Name:   "Rex",
+			Plate:  &plate,
+			Engine: engine{Kind: "diesel", Hp: &hp},
+			Axles:  []engine{{Kind: "electric", Hp: &hp}},
+		}
+	}
+
+	Expect(Struct(valid())).To(BeEmpty())
+	t2 := valid()
+	Expect(Struct(&t2)).To(BeEmpty())
+	Expect(Struct((*truck)(nil))).To(BeEmpty())
+
+	tooMuch := 1001
+	invalidPlate := "ab-1234"
+	invalid := truck{
+		Name:    "Tyrannosaurus",
+		Plate:   &invalidPlate,
+		Engine:  engine{Kind: "steam"},
+		Trailer: &engine{Kind: "diesel", Hp: &tooMuch},
+		Spares:  map[string]engine{"front": {Kind: "diesel"}},
+	}
+	Expect(Struct(invalid)).To(ConsistOf(
+		Violation{Field: "name", Rule: "max", Message: "must be at most 8 long"},
+		Violation{Field: "plate", Rule: "pattern", Message: "must match ^[A-Z]{2}-[0-9]{1,3}$"},
+		Violation{Field: "engine.kind", Rule: "enum", Message: "must be one of diesel, electric, not 'steam'"},
+		Violation{Field: "engine.hp", Rule: "required", Message: "is required"},
+		Violation{Field: "trailer.hp", Rule: "max", Message: "must be at most 1000"},
+		Violation{Field: "axles", Rule: "min", Message: "must be at least 1 long"},
+		Violation{Field: "spares.front.hp", Rule: "required", Message: "is required"},
+	))
+
+	empty := truck{Name: "", Axles: []engine{{Kind: "diesel", Hp: &hp}, {Kind: "electric"}}, Engine: engine{Kind: "diesel", Hp: &hp}}
+	violations := Struct(empty)
+	Expect(violations).To(Equal(Violations{
+		{Field: "name", Rule: "required", Message: "is required"},
+		{Field: "axles[1].hp", Rule: "required", Message: "is required"},
+	}))
+	Expect(violations.Error()).To(Equal("name is required, axles[1].hp is required"))
+}
+
+func TestParseRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	parsed, err := parseRules("required,max=3,pattern=^a,b$")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(parsed).To(HaveLen(3))
+	Expect(parsed[2].regexp.String()).To(Equal("^a,b$"))
+
+	for tag, message := range map[string]string{
+		"max=three":    "max must be a number, not 'three'",
+		"enum=":        "enum must list the values allowed",
+		"pattern=(":    "pattern '(' is invalid: error parsing regexp: missing closing ): `(`",
+		"required,odd": "unknown rule 'odd'",
+	} {
+		_, err := parseRules(tag)
+		Expect(err).To(MatchError(message), tag)
+	}
+
+	type invalid struct {
+		Name string `validate:"maximum=3"`
+	}
+	Expect(func() { Struct(invalid{}) }).To(PanicWith("invalid validate tag of field Name: unknown rule 'maximum'"))
+}
@@ -3,6 +3,7 @@ package dinosaurs
 import (
 	"context"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
@@ -14,6 +15,7 @@ type DinosaurDao interface {
 	Create(ctx context.Context, dinosaur *Dinosaur) (*Dinosaur, error)
 	Replace(ctx context.Context, dinosaur *Dinosaur) (*Dinosaur, error)
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) (*Dinosaur, error)
 	FindByIDs(ctx context.Context, ids []string) (DinosaurList, error)
 	FindBySpecies(ctx context.Context, species string) (DinosaurList, error)
 	All(ctx context.Context) (DinosaurList, error)
@@ -65,6 +67,20 @@ func (d *sqlDinosaurDao) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// Restore undeletes a soft-deleted dinosaur, it fails with gorm.ErrRecordNotFound when none is deleted with this id
+func (d *sqlDinosaurDao) Restore(ctx context.Context, id string) (*Dinosaur, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	result := g2.Unscoped().Model(&Dinosaur{}).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return d.Get(ctx, id)
+}
+
 func (d *sqlDinosaurDao) FindByIDs(ctx context.Context, ids []string) (DinosaurList, error) {
 	g2 := (*d.sessionFactory).New(ctx)
 	dinosaurs := DinosaurList{}
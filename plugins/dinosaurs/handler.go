@@ -1,6 +1,7 @@
 package dinosaurs
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -8,35 +9,51 @@ import (
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
 	"github.com/openshift-online/rh-trex-ai/pkg/api/presenters"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
 	"github.com/openshift-online/rh-trex-ai/pkg/errors"
 	"github.com/openshift-online/rh-trex-ai/pkg/handlers"
 	"github.com/openshift-online/rh-trex-ai/pkg/services"
 )
 
 var _ handlers.RestHandler = dinosaurHandler{}
+var _ handlers.PutHandler = dinosaurHandler{}
 
 type dinosaurHandler struct {
 	dinosaur DinosaurService
 	generic  services.GenericService
+	authz    auth.AuthorizationMiddleware
+	// idempotencyKeys stores the responses of the create, patch and put requests sent with an Idempotency-Key
+	idempotencyKeys services.IdempotencyKeyService
+	// createOnPut lets a PUT request create the dinosaur with the id of its path when none exists,
+	// instead of failing with 404 Not Found, see --create-on-put-kinds
+	createOnPut bool
 }
 
-func NewDinosaurHandler(dinosaur DinosaurService, generic services.GenericService) *dinosaurHandler {
+func NewDinosaurHandler(dinosaur DinosaurService, generic services.GenericService, idempotencyKeys services.IdempotencyKeyService,
+	authz auth.AuthorizationMiddleware, createOnPut bool) *dinosaurHandler {
 	return &dinosaurHandler{
-		dinosaur: dinosaur,
-		generic:  generic,
+		dinosaur:        dinosaur,
+		generic:         generic,
+		idempotencyKeys: idempotencyKeys,
+		authz:           authz,
+		createOnPut:     createOnPut,
 	}
 }
 
 func (h dinosaurHandler) Create(w http.ResponseWriter, r *http.Request) {
+	handlers.Handle(w, r, h.createConfig(r.Context()), http.StatusCreated)
+}
+
+func (h dinosaurHandler) createConfig(ctx context.Context) *handlers.HandlerConfig {
 	var dinosaur openapi.Dinosaur
-	cfg := &handlers.HandlerConfig{
+	return &handlers.HandlerConfig{
 		Body: &dinosaur,
 		Validators: []handlers.Validate{
 			handlers.ValidateEmpty(&dinosaur, "Id", "id"),
-			handlers.ValidateNotEmpty(&dinosaur, "Species", "species"),
+			handlers.ValidateStruct(&dinosaur),
+			handlers.ValidateLabels(&dinosaur, "Labels"),
 		},
 		Action: func() (interface{}, *errors.ServiceError) {
-			ctx := r.Context()
 			dino := ConvertDinosaur(dinosaur)
 			dino, err := h.dinosaur.Create(ctx, dino)
 			if err != nil {
@@ -44,36 +61,105 @@ func (h dinosaurHandler) Create(w http.ResponseWriter, r *http.Request) {
 			}
 			return PresentDinosaur(dino), nil
 		},
-		ErrorHandler: handlers.HandleError,
+		ErrorHandler:    handlers.HandleError,
+		IdempotencyKeys: h.idempotencyKeys,
 	}
-
-	handlers.Handle(w, r, cfg, http.StatusCreated)
 }
 
 func (h dinosaurHandler) Patch(w http.ResponseWriter, r *http.Request) {
-	var patch openapi.DinosaurPatchRequest
+	handlers.Handle(w, r, h.patchConfig(r.Context(), mux.Vars(r)["id"]), http.StatusOK)
+}
 
-	cfg := &handlers.HandlerConfig{
-		Body: &patch,
+// patchConfig applies the merge or JSON patch of the request onto the presented dinosaur, read under the lock
+// of the update, the patched dinosaur is validated again and replaces the stored one
+func (h dinosaurHandler) patchConfig(ctx context.Context, id string) *handlers.HandlerConfig {
+	var dinosaur openapi.Dinosaur
+	patch := &handlers.Patch{}
+	return &handlers.HandlerConfig{
+		Body:  &dinosaur,
+		Patch: patch,
 		Validators: []handlers.Validate{
-			validateDinosaurPatch(&patch),
+			handlers.ValidateStruct(&dinosaur),
+			handlers.ValidateLabels(&dinosaur, "Labels"),
 		},
 		Action: func() (interface{}, *errors.ServiceError) {
-			ctx := r.Context()
-			id := mux.Vars(r)["id"]
-			dino, err := h.dinosaur.Replace(ctx, &Dinosaur{
-				Meta:    api.Meta{ID: id},
-				Species: *patch.Species,
+			dino, err := h.dinosaur.Patch(ctx, id, func(found *Dinosaur) (*Dinosaur, *errors.ServiceError) {
+				if err := patch.Apply(PresentDinosaur(found)); err != nil {
+					return nil, err
+				}
+				patched := ConvertDinosaur(dinosaur)
+				patched.ID = id
+				return patched, nil
 			})
 			if err != nil {
 				return nil, err
 			}
 			return PresentDinosaur(dino), nil
 		},
-		ErrorHandler: handlers.HandleError,
+		ErrorHandler:    handlers.HandleError,
+		IdempotencyKeys: h.idempotencyKeys,
+	}
+}
+
+// Put replaces the dinosaur with the one of the request body, the fields it leaves out are cleared
+func (h dinosaurHandler) Put(w http.ResponseWriter, r *http.Request) {
+	handlers.Handle(w, r, h.putConfig(r.Context(), mux.Vars(r)["id"]), http.StatusOK)
+}
+
+// putConfig replaces the dinosaur, or creates it with the id of the path when createOnPut is set and it doesn't exist
+func (h dinosaurHandler) putConfig(ctx context.Context, id string) *handlers.HandlerConfig {
+	var dinosaur openapi.Dinosaur
+	return &handlers.HandlerConfig{
+		Body: &dinosaur,
+		Validators: []handlers.Validate{
+			handlers.ValidateIDMatches(&dinosaur, "Id", id),
+			handlers.ValidateStruct(&dinosaur),
+			handlers.ValidateLabels(&dinosaur, "Labels"),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			dino := ConvertDinosaur(dinosaur)
+			dino.ID = id
+			if _, err := h.dinosaur.Get(ctx, id); err != nil {
+				if !err.Is404() || !h.createOnPut {
+					return nil, err
+				}
+				if err := api.ValidateID(id); err != nil {
+					return nil, err
+				}
+				// the route only authorizes the update, creating needs the create action too
+				if err := h.authz.Authorize(ctx, auth.ActionCreate, "Dinosaur"); err != nil {
+					return nil, err
+				}
+				created, err := h.dinosaur.Create(ctx, dino)
+				if err != nil {
+					return nil, err
+				}
+				return handlers.Created{Object: PresentDinosaur(created)}, nil
+			}
+			replaced, err := h.dinosaur.Replace(ctx, dino)
+			if err != nil {
+				return nil, err
+			}
+			return PresentDinosaur(replaced), nil
+		},
+		ErrorHandler:    handlers.HandleError,
+		IdempotencyKeys: h.idempotencyKeys,
 	}
+}
 
-	handlers.Handle(w, r, cfg, http.StatusOK)
+// Bulk creates, patches and deletes dinosaurs in a single transaction, validated as in their own requests
+func (h dinosaurHandler) Bulk(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.BulkConfig{
+		Create:      h.createConfig,
+		Patch:       h.patchConfig,
+		Delete:      h.deleteConfig,
+		Transaction: h.generic.Transaction,
+		// every operation is authorized for its own action
+		Authorize: func(ctx context.Context, action string) *errors.ServiceError {
+			return h.authz.Authorize(ctx, action, "Dinosaur")
+		},
+	}
+	handlers.HandleBulk(w, r, cfg)
 }
 
 func (h dinosaurHandler) List(w http.ResponseWriter, r *http.Request) {
@@ -100,7 +186,7 @@ func (h dinosaurHandler) List(w http.ResponseWriter, r *http.Request) {
 				dinoList.Items = append(dinoList.Items, converted)
 			}
 			if listArgs.Fields != nil {
-				filteredItems, err := presenters.SliceFilter(listArgs.Fields, dinoList.Items)
+				filteredItems, err := presenters.SliceFilter(listArgs.Fields, dinoList)
 				if err != nil {
 					return nil, err
 				}
@@ -113,40 +199,82 @@ func (h dinosaurHandler) List(w http.ResponseWriter, r *http.Request) {
 	handlers.HandleList(w, r, cfg)
 }
 
+func (h dinosaurHandler) Aggregate(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+
+			aggregateArgs := services.NewAggregateArguments(r.URL.Query())
+			aggregations, err := h.generic.Aggregate(ctx, "username", aggregateArgs, &Dinosaur{})
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentAggregationList(aggregations), nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
 func (h dinosaurHandler) Get(w http.ResponseWriter, r *http.Request) {
 	cfg := &handlers.HandlerConfig{
 		Action: func() (interface{}, *errors.ServiceError) {
 			id := mux.Vars(r)["id"]
 			ctx := r.Context()
-			dinosaur, err := h.dinosaur.Get(ctx, id)
+
+			getArgs := services.NewGetArguments(r.URL.Query())
+			if getArgs.Fields == nil && getArgs.Preloads == nil {
+				dinosaur, err := h.dinosaur.Get(ctx, id)
+				if err != nil {
+					return nil, err
+				}
+				return PresentDinosaur(dinosaur), nil
+			}
+
+			// the dinosaur service loads whole dinosaurs, selecting the fields and including
+			// the related resources is left to the generic service
+			var dinosaur Dinosaur
+			err := h.generic.Get(ctx, "username", id, getArgs, &dinosaur)
 			if err != nil {
 				return nil, err
 			}
 
-			return PresentDinosaur(dinosaur), nil
+			presented := PresentDinosaur(&dinosaur)
+			if getArgs.Fields != nil {
+				return presenters.ObjectFilter(getArgs.Fields, presented)
+			}
+			return presented, nil
 		},
 	}
 
 	handlers.HandleGet(w, r, cfg)
 }
 
-func validateDinosaurPatch(patch *openapi.DinosaurPatchRequest) handlers.Validate {
-	return func() *errors.ServiceError {
-		if patch.Species == nil {
-			return errors.Validation("species cannot be nil")
-		}
-		if len(*patch.Species) == 0 {
-			return errors.Validation("species cannot be empty")
-		}
-		return nil
+func (h dinosaurHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Validators: []handlers.Validate{
+			handlers.ValidateAdmin(r, "restore dinosaurs"),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			ctx := r.Context()
+			dino, err := h.dinosaur.Restore(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return PresentDinosaur(dino), nil
+		},
 	}
+	handlers.HandleAction(w, r, cfg, http.StatusOK)
 }
 
 func (h dinosaurHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	cfg := &handlers.HandlerConfig{
+	handlers.HandleDelete(w, r, h.deleteConfig(r.Context(), mux.Vars(r)["id"]), http.StatusNoContent)
+}
+
+func (h dinosaurHandler) deleteConfig(ctx context.Context, id string) *handlers.HandlerConfig {
+	return &handlers.HandlerConfig{
 		Action: func() (interface{}, *errors.ServiceError) {
-			id := mux.Vars(r)["id"]
-			ctx := r.Context()
 			err := h.dinosaur.Delete(ctx, id)
 			if err != nil {
 				return nil, err
@@ -154,5 +282,4 @@ func (h dinosaurHandler) Delete(w http.ResponseWriter, r *http.Request) {
 			return nil, nil
 		},
 	}
-	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
 }
@@ -0,0 +1,83 @@
+package dinosaurs
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gm "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	daomocks "github.com/openshift-online/rh-trex-ai/pkg/dao/mocks"
+	dbmocks "github.com/openshift-online/rh-trex-ai/pkg/db/mocks"
+	"github.com/openshift-online/rh-trex-ai/pkg/handlers"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+func TestDinosaurPutMissing(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	newHandler := func(createOnPut bool) *dinosaurHandler {
+		events := services.NewEventService(daomocks.NewEventDao())
+		dinoService := NewDinosaurService(dbmocks.NewMockAdvisoryLockFactory(), NewMockDinosaurDao(), events)
+		return NewDinosaurHandler(dinoService, nil, nil, auth.NewAuthzMiddlewareMock(), createOnPut)
+	}
+	put := func(h *dinosaurHandler) (interface{}, int) {
+		cfg := h.putConfig(context.Background(), "apatosaurus")
+		*cfg.Body.(*openapi.Dinosaur) = openapi.Dinosaur{Species: "Apatosaurus"}
+		result, err := cfg.Action()
+		if err != nil {
+			return nil, err.HttpCode
+		}
+		return result, http.StatusOK
+	}
+
+	_, status := put(newHandler(false))
+	gm.Expect(status).To(gm.Equal(http.StatusNotFound))
+
+	result, status := put(newHandler(true))
+	gm.Expect(status).To(gm.Equal(http.StatusOK))
+	created, ok := result.(handlers.Created)
+	gm.Expect(ok).To(gm.BeTrue())
+	gm.Expect(*created.Object.(openapi.Dinosaur).Id).To(gm.Equal("apatosaurus"))
+}
+
+func TestDinosaurPutCreateAuthorization(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	policy := `
+roles:
+- name: dinosaur-editor
+  rules:
+  - kinds: [Dinosaur]
+    verbs: [update]
+bindings:
+- role: dinosaur-editor
+  subjects:
+  - username: alice
+`
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	gm.Expect(os.WriteFile(file, []byte(policy), 0600)).To(gm.Succeed())
+	authorizer, err := auth.NewRBACAuthorizer(file, time.Minute)
+	gm.Expect(err).NotTo(gm.HaveOccurred())
+
+	events := services.NewEventService(daomocks.NewEventDao())
+	dinoService := NewDinosaurService(dbmocks.NewMockAdvisoryLockFactory(), NewMockDinosaurDao(), events)
+	h := NewDinosaurHandler(dinoService, nil, nil, auth.NewAuthzMiddleware(authorizer), true)
+
+	// alice may update the dinosaurs but not create them, even with a PUT
+	ctx := auth.SetUsernameContext(context.Background(), "alice")
+	cfg := h.putConfig(ctx, "apatosaurus")
+	*cfg.Body.(*openapi.Dinosaur) = openapi.Dinosaur{Species: "Apatosaurus"}
+	_, serviceErr := cfg.Action()
+	gm.Expect(serviceErr).NotTo(gm.BeNil())
+	gm.Expect(serviceErr.HttpCode).To(gm.Equal(http.StatusForbidden))
+
+	_, serviceErr = dinoService.Get(ctx, "apatosaurus")
+	gm.Expect(serviceErr).NotTo(gm.BeNil())
+	gm.Expect(serviceErr.Is404()).To(gm.BeTrue())
+}
@@ -2,13 +2,17 @@ package dinosaurs_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/api/presenters"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
 	"github.com/openshift-online/rh-trex-ai/pkg/dao"
 	"github.com/openshift-online/rh-trex-ai/plugins/dinosaurs"
 
@@ -112,7 +116,12 @@ func TestDinosaurPatch(t *testing.T) {
 		Patch(h.RestURL(fmt.Sprintf("/dinosaurs/%s", *dinosaur.Id)))
 	Expect(err).NotTo(HaveOccurred(), "Error posting object:  %v", err)
 	Expect(restyResp.StatusCode()).To(Equal(http.StatusBadRequest))
-	Expect(restyResp.String()).To(ContainSubstring("species cannot be empty"))
+	Expect(restyResp.String()).To(ContainSubstring("species is required"))
+	var serviceErr openapi.Error
+	Expect(json.Unmarshal(restyResp.Body(), &serviceErr)).To(Succeed())
+	Expect(serviceErr.GetDetails()).To(Equal([]openapi.FieldViolation{
+		*openapi.NewFieldViolation("species", "required", "species is required"),
+	}))
 
 	Eventually(func() error {
 		dao := dao.NewEventDao(&h.Env().Database.SessionFactory)
@@ -175,6 +184,130 @@ func TestDinosaurListSearch(t *testing.T) {
 	Expect(*list.Items[0].Id).To(Equal(dinoList[0].ID))
 }
 
+func TestDinosaurListRelativeTimeSearch(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	_, err := newDinosaurList("raptor", 3)
+	Expect(err).NotTo(HaveOccurred())
+
+	list, _, err := client.DefaultAPI.ApiRhTrexV1DinosaursGet(ctx).Search("created_at > now-1h and updated_at <= now").Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error getting dinosaur list: %v", err)
+	Expect(list.Total).To(Equal(int32(3)))
+
+	list, _, err = client.DefaultAPI.ApiRhTrexV1DinosaursGet(ctx).Search("created_at < today-1d").Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error getting dinosaur list: %v", err)
+	Expect(list.Total).To(Equal(int32(0)))
+
+	_, resp, err := client.DefaultAPI.ApiRhTrexV1DinosaursGet(ctx).Search("species > now-1h").Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+}
+
+func TestDinosaurListTextSearch(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	for _, species := range []string{"sharp teeth and claws", "teeth, teeth and more teeth", "long neck"} {
+		_, err := newDinosaur(species)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	// the most relevant match comes first
+	list, _, err := client.DefaultAPI.ApiRhTrexV1DinosaursGet(ctx).Search("species ~= 'teeth'").Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error getting dinosaur list: %v", err)
+	Expect(list.Items).To(HaveLen(2))
+	Expect(list.Items[0].Species).To(Equal("teeth, teeth and more teeth"))
+	Expect(list.Items[1].Species).To(Equal("sharp teeth and claws"))
+
+	list, _, err = client.DefaultAPI.ApiRhTrexV1DinosaursGet(ctx).Search("species ilike 'SHARP%'").Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error getting dinosaur list: %v", err)
+	Expect(list.Items).To(HaveLen(1))
+	Expect(list.Items[0].Species).To(Equal("sharp teeth and claws"))
+
+	_, resp, err := client.DefaultAPI.ApiRhTrexV1DinosaursGet(ctx).Search("id ~= 'teeth'").Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+}
+
+func TestDinosaurListFields(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	_, err := newDinosaurList("bronto", 3)
+	Expect(err).NotTo(HaveOccurred())
+
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+	restyResp, err := resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetQueryParam("fields", "species").
+		Get(h.RestURL("/dinosaurs"))
+	Expect(err).NotTo(HaveOccurred(), "Error getting dinosaur list: %v", err)
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+
+	var list presenters.ProjectionList
+	Expect(json.Unmarshal(restyResp.Body(), &list)).To(Succeed())
+	Expect(list.Kind).To(Equal("DinosaurList"))
+	Expect(list.Total).To(Equal(int32(3)))
+	Expect(list.Items).To(HaveLen(3))
+	for _, item := range list.Items {
+		Expect(item).To(HaveLen(2))
+		Expect(item).To(HaveKey("id"))
+		Expect(item["species"]).To(HavePrefix("bronto_"))
+	}
+
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetQueryParam("fields", "species,weight").
+		Get(h.RestURL("/dinosaurs"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusBadRequest))
+}
+
+func TestDinosaurGetFields(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	dino, err := newDinosaur(h.NewID())
+	Expect(err).NotTo(HaveOccurred())
+
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+	restyResp, err := resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetQueryParam("fields", "species").
+		Get(h.RestURL("/dinosaurs/" + dino.ID))
+	Expect(err).NotTo(HaveOccurred(), "Error getting dinosaur: %v", err)
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+
+	var item map[string]interface{}
+	Expect(json.Unmarshal(restyResp.Body(), &item)).To(Succeed())
+	Expect(item).To(HaveLen(2))
+	Expect(item["id"]).To(Equal(dino.ID))
+	Expect(item["species"]).To(Equal(dino.Species))
+
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetQueryParam("fields", "species,weight").
+		Get(h.RestURL("/dinosaurs/" + dino.ID))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusBadRequest))
+
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetQueryParam("include", "eggs").
+		Get(h.RestURL("/dinosaurs/" + dino.ID))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusBadRequest))
+}
+
 func TestUpdateDinosaurWithRacingRequests_BlockingAdvisoryLock(t *testing.T) {
 	testUpdateDinosaurWithRacingRequests(t, true, true, 2)
 }
@@ -193,6 +326,42 @@ func TestUpdateDinosaurWithRacingRequests_WithoutLock(t *testing.T) {
 	// testUpdateDinosaurWithRacingRequests(t, false, false, 2)
 }
 
+// TestDinosaurConcurrentPatches patches two fields of a dinosaur at once, the patches are applied under the
+// lock of the update so that neither overwrites the other
+func TestDinosaurConcurrentPatches(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	for i := 0; i < 5; i++ {
+		dino, err := newDinosaur("Stegosaurus")
+		Expect(err).NotTo(HaveOccurred())
+
+		species := fmt.Sprintf("Stegosaurus-%d", i)
+		labels := map[string]string{"era": "jurassic"}
+		var speciesErr, labelsErr error
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, _, speciesErr = client.DefaultAPI.ApiRhTrexV1DinosaursIdPatch(ctx, dino.ID).DinosaurPatchRequest(openapi.DinosaurPatchRequest{Species: &species}).Execute()
+		}()
+		go func() {
+			defer wg.Done()
+			_, _, labelsErr = client.DefaultAPI.ApiRhTrexV1DinosaursIdPatch(ctx, dino.ID).DinosaurPatchRequest(openapi.DinosaurPatchRequest{Labels: &labels}).Execute()
+		}()
+		wg.Wait()
+		Expect(speciesErr).NotTo(HaveOccurred(), "Error patching the species: %v", speciesErr)
+		Expect(labelsErr).NotTo(HaveOccurred(), "Error patching the labels: %v", labelsErr)
+
+		found, _, err := client.DefaultAPI.ApiRhTrexV1DinosaursIdGet(ctx, dino.ID).Execute()
+		Expect(err).NotTo(HaveOccurred(), "Error getting object:  %v", err)
+		Expect(found.Species).To(Equal(species))
+		Expect(found.GetLabels()).To(Equal(labels))
+	}
+}
+
 func testUpdateDinosaurWithRacingRequests(t *testing.T, useAdvisoryLock, useBlockingAdvisoryLock bool, expectedUpdates int) {
 	h, client := test.RegisterIntegration(t)
 
@@ -289,3 +458,451 @@ func testUpdateDinosaurWithRacingRequests(t *testing.T, useAdvisoryLock, useBloc
 		return nil
 	}, 5*time.Second, 1*time.Second).Should(Succeed())
 }
+
+func TestDinosaurAggregate(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	for _, species := range []string{"rex", "rex", "rex", "stego", "raptor"} {
+		_, err := newDinosaur(species)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	aggregations, _, err := client.DefaultAPI.ApiRhTrexV1DinosaursAggregateGet(ctx).
+		Search("species <> 'raptor'").GroupBy("species").Min("created_at").Max("created_at").Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error aggregating dinosaurs: %v", err)
+	Expect(aggregations.Kind).To(Equal("AggregationList"))
+	Expect(aggregations.Items).To(HaveLen(2))
+	Expect(aggregations.Items[0].Group).To(Equal(map[string]interface{}{"species": "rex"}))
+	Expect(aggregations.Items[0].Count).To(Equal(int64(3)))
+	Expect(aggregations.Items[0].Min).To(HaveKey("created_at"))
+	Expect(aggregations.Items[0].Max).To(HaveKey("created_at"))
+	Expect(aggregations.Items[1].Group).To(Equal(map[string]interface{}{"species": "stego"}))
+	Expect(aggregations.Items[1].Count).To(Equal(int64(1)))
+
+	// without grouping, all the dinosaurs make up a single group
+	aggregations, _, err = client.DefaultAPI.ApiRhTrexV1DinosaursAggregateGet(ctx).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error aggregating dinosaurs: %v", err)
+	Expect(aggregations.Items).To(HaveLen(1))
+	Expect(aggregations.Items[0].Count).To(Equal(int64(5)))
+
+	_, resp, err := client.DefaultAPI.ApiRhTrexV1DinosaursAggregateGet(ctx).GroupBy("weight").Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+}
+
+func TestDinosaurLabels(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	create := func(species string, labels map[string]string) *openapi.Dinosaur {
+		dino := openapi.Dinosaur{Species: species, Labels: &labels}
+		dinosaur, resp, err := client.DefaultAPI.ApiRhTrexV1DinosaursPost(ctx).Dinosaur(dino).Execute()
+		Expect(err).NotTo(HaveOccurred(), "Error posting object:  %v", err)
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+		Expect(dinosaur.GetLabels()).To(Equal(labels))
+		return dinosaur
+	}
+	rex := create("rex", map[string]string{"era": "cretaceous", "diet": "carnivore"})
+	create("stego", map[string]string{"era": "jurassic", "diet": "herbivore"})
+	create("raptor", map[string]string{"era": "cretaceous", "diet": "carnivore", "example.com/pack": "true"})
+
+	list := func(selector string) []string {
+		dinos, _, err := client.DefaultAPI.ApiRhTrexV1DinosaursGet(ctx).LabelSelector(selector).OrderBy("species asc").Execute()
+		Expect(err).NotTo(HaveOccurred(), "Error getting dinosaur list: %v", err)
+		species := []string{}
+		for _, dino := range dinos.Items {
+			species = append(species, dino.Species)
+		}
+		return species
+	}
+	Expect(list("era=cretaceous")).To(Equal([]string{"raptor", "rex"}))
+	Expect(list("era=cretaceous,!example.com/pack")).To(Equal([]string{"rex"}))
+	Expect(list("diet in (herbivore, omnivore)")).To(Equal([]string{"stego"}))
+	Expect(list("era notin (jurassic),diet!=herbivore,example.com/pack")).To(Equal([]string{"raptor"}))
+
+	// a merge patch only changes the labels it gives
+	labels := map[string]string{"era": "cretaceous", "diet": "scavenger"}
+	species := "rex"
+	patched, _, err := client.DefaultAPI.ApiRhTrexV1DinosaursIdPatch(ctx, *rex.Id).DinosaurPatchRequest(openapi.DinosaurPatchRequest{Species: &species, Labels: &labels}).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error patching object:  %v", err)
+	Expect(patched.GetLabels()).To(Equal(labels))
+	Expect(list("diet=scavenger")).To(Equal([]string{"rex"}))
+
+	species = "t-rex"
+	patched, _, err = client.DefaultAPI.ApiRhTrexV1DinosaursIdPatch(ctx, *rex.Id).DinosaurPatchRequest(openapi.DinosaurPatchRequest{Species: &species}).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error patching object:  %v", err)
+	Expect(patched.GetLabels()).To(Equal(labels))
+
+	_, resp, err := client.DefaultAPI.ApiRhTrexV1DinosaursGet(ctx).LabelSelector("era in (cretaceous").Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+
+	invalid := map[string]string{"-era": "cretaceous"}
+	_, resp, err = client.DefaultAPI.ApiRhTrexV1DinosaursPost(ctx).Dinosaur(openapi.Dinosaur{Species: "dodo", Labels: &invalid}).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+}
+
+func TestDinosaurFieldAllowlist(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	_, err := newDinosaurList("allowed", 2)
+	Expect(err).NotTo(HaveOccurred())
+
+	list, _, err := client.DefaultAPI.ApiRhTrexV1DinosaursGet(ctx).
+		Search("species like 'allowed%'").OrderBy("created_at desc").Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error listing dinosaurs: %v", err)
+	Expect(list.Items).To(HaveLen(2))
+
+	// labels are selected with labelSelector, they are neither searchable nor sortable
+	for _, request := range []openapi.ApiApiRhTrexV1DinosaursGetRequest{
+		client.DefaultAPI.ApiRhTrexV1DinosaursGet(ctx).Search("labels = '{}'"),
+		client.DefaultAPI.ApiRhTrexV1DinosaursGet(ctx).OrderBy("labels"),
+	} {
+		_, resp, err := request.Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+
+		var serviceErr openapi.Error
+		Expect(json.Unmarshal(err.(*openapi.GenericOpenAPIError).Body(), &serviceErr)).To(Succeed())
+		Expect(serviceErr.GetCode()).To(Equal("rh-trex-23"))
+	}
+}
+
+func TestDinosaurSoftDelete(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+
+	dinos, err := newDinosaurList("extinct", 2)
+	Expect(err).NotTo(HaveOccurred())
+
+	restyResp, err := resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		Delete(h.RestURL(fmt.Sprintf("/dinosaurs/%s", dinos[0].ID)))
+	Expect(err).NotTo(HaveOccurred(), "Error deleting object:  %v", err)
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusNoContent))
+
+	list, _, err := client.DefaultAPI.ApiRhTrexV1DinosaursGet(ctx).Search("species like 'extinct%'").Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error listing dinosaurs: %v", err)
+	Expect(list.Items).To(HaveLen(1))
+
+	// only the admins see and restore the deleted dinosaurs
+	_, resp, err := client.DefaultAPI.ApiRhTrexV1DinosaursGet(ctx).IncludeDeleted(true).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+	_, resp, err = client.DefaultAPI.ApiRhTrexV1DinosaursIdRestorePost(ctx, dinos[0].ID).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+
+	auth.SetAdmins([]string{strings.ToLower(account.Username())})
+	defer auth.SetAdmins(h.Env().Config.Server.AdminUsers)
+
+	list, _, err = client.DefaultAPI.ApiRhTrexV1DinosaursGet(ctx).Search("species like 'extinct%'").IncludeDeleted(true).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error listing dinosaurs: %v", err)
+	Expect(list.Items).To(HaveLen(2))
+
+	list, _, err = client.DefaultAPI.ApiRhTrexV1DinosaursGet(ctx).Search("species like 'extinct%'").OnlyDeleted(true).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error listing dinosaurs: %v", err)
+	Expect(list.Items).To(HaveLen(1))
+	Expect(*list.Items[0].Id).To(Equal(dinos[0].ID))
+	Expect(list.Items[0].DeletedAt).NotTo(BeNil())
+
+	dino, resp, err := client.DefaultAPI.ApiRhTrexV1DinosaursIdRestorePost(ctx, dinos[0].ID).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error restoring dinosaur: %v", err)
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	Expect(dino.DeletedAt).To(BeNil())
+
+	// a dinosaur which is not deleted cannot be restored
+	_, resp, err = client.DefaultAPI.ApiRhTrexV1DinosaursIdRestorePost(ctx, dinos[0].ID).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusConflict))
+	_, resp, err = client.DefaultAPI.ApiRhTrexV1DinosaursIdRestorePost(ctx, "foo").Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+}
+
+func TestDinosaurBulk(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	dinos, err := newDinosaurList("bulky", 2)
+	Expect(err).NotTo(HaveOccurred())
+
+	operations := []openapi.BulkOperation{
+		{Op: "create", Item: map[string]interface{}{"species": "bulky_created"}},
+		{Op: "patch", Id: &dinos[0].ID, Item: map[string]interface{}{"species": "bulky_patched"}},
+		{Op: "delete", Id: &dinos[1].ID},
+	}
+	results, resp, err := client.DefaultAPI.ApiRhTrexV1DinosaursBulkPost(ctx).BulkRequest(*openapi.NewBulkRequest(operations)).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error running bulk operations: %v", err)
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	Expect(results.Items).To(HaveLen(3))
+	Expect(results.Items[0].Status).To(Equal(int32(http.StatusCreated)))
+	Expect(results.Items[0].Item).To(HaveKeyWithValue("species", "bulky_created"))
+	Expect(results.Items[1].Status).To(Equal(int32(http.StatusOK)))
+	Expect(results.Items[2].Status).To(Equal(int32(http.StatusNoContent)))
+
+	list, _, err := client.DefaultAPI.ApiRhTrexV1DinosaursGet(ctx).Search("species like 'bulky%'").OrderBy("species").Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error listing dinosaurs: %v", err)
+	Expect(list.Items).To(HaveLen(2))
+	Expect(list.Items[0].Species).To(Equal("bulky_created"))
+	Expect(list.Items[1].Species).To(Equal("bulky_patched"))
+
+	// every committed change emits its event
+	eventDao := dao.NewEventDao(&h.Env().Database.SessionFactory)
+	events, err := eventDao.FindByIDs(ctx, []string{results.Items[0].GetId(), dinos[0].ID, dinos[1].ID})
+	Expect(err).NotTo(HaveOccurred(), "Error getting events:  %v", err)
+	Expect(contains(api.CreateEventType, events)).To(BeTrue())
+	Expect(contains(api.UpdateEventType, events)).To(BeTrue())
+	Expect(contains(api.DeleteEventType, events)).To(BeTrue())
+
+	// all the operations are rolled back when one fails
+	operations = []openapi.BulkOperation{
+		{Op: "create", Item: map[string]interface{}{"species": "bulky_rolled_back"}},
+		{Op: "patch", Id: &dinos[0].ID, Item: map[string]interface{}{"species": ""}},
+	}
+	_, resp, err = client.DefaultAPI.ApiRhTrexV1DinosaursBulkPost(ctx).BulkRequest(*openapi.NewBulkRequest(operations)).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+	Expect(string(err.(*openapi.GenericOpenAPIError).Body())).To(ContainSubstring("Operation 1 failed, no operation was committed: species is required"))
+
+	list, _, err = client.DefaultAPI.ApiRhTrexV1DinosaursGet(ctx).Search("species like 'bulky%'").Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error listing dinosaurs: %v", err)
+	Expect(list.Items).To(HaveLen(2))
+
+	// the operations which succeed are committed in partial mode
+	missing := "missing"
+	operations = []openapi.BulkOperation{
+		{Op: "create", Item: map[string]interface{}{"species": "bulky_partial"}},
+		{Op: "patch", Id: &missing, Item: map[string]interface{}{"species": "bulky_missing"}},
+	}
+	results, resp, err = client.DefaultAPI.ApiRhTrexV1DinosaursBulkPost(ctx).BulkRequest(*openapi.NewBulkRequest(operations)).Partial(true).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error running bulk operations: %v", err)
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	Expect(results.Items[0].Status).To(Equal(int32(http.StatusCreated)))
+	Expect(results.Items[1].Status).To(Equal(int32(http.StatusNotFound)))
+	Expect(results.Items[1].Error).NotTo(BeNil())
+
+	list, _, err = client.DefaultAPI.ApiRhTrexV1DinosaursGet(ctx).Search("species like 'bulky%'").Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error listing dinosaurs: %v", err)
+	Expect(list.Items).To(HaveLen(3))
+}
+
+func TestDinosaurIdempotencyKey(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+
+	post := func(key string, body string) *resty.Response {
+		restyResp, err := resty.R().
+			SetHeader("Content-Type", "application/json").
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+			SetHeader("Idempotency-Key", key).
+			SetBody(body).
+			Post(h.RestURL("/dinosaurs"))
+		Expect(err).NotTo(HaveOccurred(), "Error posting object:  %v", err)
+		return restyResp
+	}
+
+	key := h.NewID()
+	first := post(key, `{"species": "idempotent"}`)
+	Expect(first.StatusCode()).To(Equal(http.StatusCreated))
+	Expect(first.Header().Get("Idempotent-Replayed")).To(BeEmpty())
+
+	// the retry gets the same dinosaur instead of creating another one
+	retry := post(key, `{"species": "idempotent"}`)
+	Expect(retry.StatusCode()).To(Equal(http.StatusCreated))
+	Expect(retry.Header().Get("Idempotent-Replayed")).To(Equal("true"))
+	Expect(retry.String()).To(Equal(first.String()))
+
+	reused := post(key, `{"species": "another"}`)
+	Expect(reused.StatusCode()).To(Equal(http.StatusUnprocessableEntity))
+
+	dinos, err := dinosaurs.Service(&h.Env().Services).All(ctx)
+	Expect(err).To(BeNil())
+	created := 0
+	for _, dino := range dinos {
+		if dino.Species == "idempotent" || dino.Species == "another" {
+			created++
+		}
+	}
+	Expect(created).To(Equal(1))
+}
+
+func TestDinosaurDryRun(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	created, resp, err := client.DefaultAPI.ApiRhTrexV1DinosaursPost(ctx).Dinosaur(openapi.Dinosaur{Species: "dry-run"}).DryRun(true).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error posting object:  %v", err)
+	Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+	Expect(*created.Id).NotTo(BeEmpty())
+	Expect(created.Species).To(Equal("dry-run"))
+
+	// nothing was persisted, the event of the creation included
+	_, resp, err = client.DefaultAPI.ApiRhTrexV1DinosaursIdGet(ctx, *created.Id).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+	events, err := dao.NewEventDao(&h.Env().Database.SessionFactory).FindByIDs(ctx, []string{*created.Id})
+	Expect(err).NotTo(HaveOccurred(), "Error getting events:  %v", err)
+	Expect(events).To(BeEmpty())
+
+	// the validation applies to the dry runs
+	_, resp, err = client.DefaultAPI.ApiRhTrexV1DinosaursPost(ctx).Dinosaur(openapi.Dinosaur{Species: ""}).DryRun(true).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+
+	dino, err := newDinosaur("Brontosaurus")
+	Expect(err).NotTo(HaveOccurred())
+
+	species := "Dodo"
+	patched, resp, err := client.DefaultAPI.ApiRhTrexV1DinosaursIdPatch(ctx, dino.ID).DinosaurPatchRequest(openapi.DinosaurPatchRequest{Species: &species}).DryRun(true).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error patching object:  %v", err)
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	Expect(patched.Species).To(Equal(species))
+
+	found, _, err := client.DefaultAPI.ApiRhTrexV1DinosaursIdGet(ctx, dino.ID).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(found.Species).To(Equal("Brontosaurus"))
+
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+	restyResp, err := resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		Delete(h.RestURL(fmt.Sprintf("/dinosaurs/%s?dryRun=true", dino.ID)))
+	Expect(err).NotTo(HaveOccurred(), "Error deleting object:  %v", err)
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusNoContent))
+
+	_, resp, err = client.DefaultAPI.ApiRhTrexV1DinosaursIdGet(ctx, dino.ID).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+	restyResp, err = resty.R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetBody(`{"species": "dry-run"}`).
+		Post(h.RestURL("/dinosaurs?dryRun=maybe"))
+	Expect(err).NotTo(HaveOccurred(), "Error posting object:  %v", err)
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusBadRequest))
+}
+
+func TestDinosaurPatchDocuments(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+
+	dino, err := newDinosaur("Brontosaurus")
+	Expect(err).NotTo(HaveOccurred())
+
+	patch := func(contentType string, body string) (*resty.Response, openapi.Dinosaur) {
+		restyResp, err := resty.R().
+			SetHeader("Content-Type", contentType).
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+			SetBody(body).
+			Patch(h.RestURL(fmt.Sprintf("/dinosaurs/%s", dino.ID)))
+		Expect(err).NotTo(HaveOccurred(), "Error patching object:  %v", err)
+		var patched openapi.Dinosaur
+		if restyResp.StatusCode() == http.StatusOK {
+			Expect(json.Unmarshal(restyResp.Body(), &patched)).To(Succeed())
+		}
+		return restyResp, patched
+	}
+
+	resp, patched := patch("application/json-patch+json", `[
+		{"op": "test", "path": "/species", "value": "Brontosaurus"},
+		{"op": "replace", "path": "/species", "value": "Apatosaurus"},
+		{"op": "add", "path": "/labels", "value": {"era": "jurassic", "diet": "herbivore"}}
+	]`)
+	Expect(resp.StatusCode()).To(Equal(http.StatusOK), resp.String())
+	Expect(patched.Species).To(Equal("Apatosaurus"))
+	Expect(patched.GetLabels()).To(Equal(map[string]string{"era": "jurassic", "diet": "herbivore"}))
+
+	resp, patched = patch("application/merge-patch+json", `{"labels": {"diet": null}}`)
+	Expect(resp.StatusCode()).To(Equal(http.StatusOK), resp.String())
+	Expect(patched.Species).To(Equal("Apatosaurus"))
+	Expect(patched.GetLabels()).To(Equal(map[string]string{"era": "jurassic"}))
+
+	// a failed test operation, a read-only field or an invalid result leave the dinosaur unchanged
+	resp, _ = patch("application/json-patch+json", `[
+		{"op": "replace", "path": "/species", "value": "Diplodocus"},
+		{"op": "test", "path": "/species", "value": "Brontosaurus"}
+	]`)
+	Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest))
+	resp, _ = patch("application/json-patch+json", `[{"op": "replace", "path": "/id", "value": "another"}]`)
+	Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest))
+	Expect(resp.String()).To(ContainSubstring("id is read-only"))
+	resp, _ = patch("application/merge-patch+json", `{"species": null}`)
+	Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest))
+	Expect(resp.String()).To(ContainSubstring("no value given for required property species"))
+	resp, _ = patch("text/plain", `{"species": "Diplodocus"}`)
+	Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest))
+
+	found, err := dinosaurs.Service(&h.Env().Services).Get(ctx, dino.ID)
+	Expect(err).To(BeNil())
+	Expect(found.Species).To(Equal("Apatosaurus"))
+}
+
+func TestDinosaurPut(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	dino, err := newDinosaur("Brontosaurus")
+	Expect(err).NotTo(HaveOccurred())
+
+	// the labels left out of the body are cleared
+	labels := map[string]string{"era": "jurassic"}
+	replaced, resp, err := client.DefaultAPI.ApiRhTrexV1DinosaursIdPut(ctx, dino.ID).Dinosaur(openapi.Dinosaur{Species: "Apatosaurus", Labels: &labels}).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error replacing object:  %v", err)
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	Expect(*replaced.Id).To(Equal(dino.ID))
+	Expect(replaced.Species).To(Equal("Apatosaurus"))
+	Expect(replaced.GetLabels()).To(Equal(labels))
+	Expect(*replaced.CreatedAt).To(BeTemporally("~", dino.CreatedAt))
+
+	replaced, resp, err = client.DefaultAPI.ApiRhTrexV1DinosaursIdPut(ctx, dino.ID).Dinosaur(openapi.Dinosaur{Species: "Apatosaurus"}).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error replacing object:  %v", err)
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	Expect(replaced.GetLabels()).To(BeEmpty())
+
+	another := "another"
+	_, resp, err = client.DefaultAPI.ApiRhTrexV1DinosaursIdPut(ctx, dino.ID).Dinosaur(openapi.Dinosaur{Id: &another, Species: "Apatosaurus"}).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+
+	// the integration environment sets --create-on-put-kinds=Dinosaur, a missing dinosaur is created
+	id := "apatosaurus-" + strings.ToLower(h.NewID())
+	created, resp, err := client.DefaultAPI.ApiRhTrexV1DinosaursIdPut(ctx, id).Dinosaur(openapi.Dinosaur{Species: "Apatosaurus"}).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error creating object:  %v", err)
+	Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+	Expect(*created.Id).To(Equal(id))
+
+	// putting the same dinosaur again is a no-op replace
+	replaced, resp, err = client.DefaultAPI.ApiRhTrexV1DinosaursIdPut(ctx, id).Dinosaur(openapi.Dinosaur{Species: "Apatosaurus"}).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error replacing object:  %v", err)
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	Expect(*replaced.UpdatedAt).To(Equal(*created.UpdatedAt))
+
+	_, resp, err = client.DefaultAPI.ApiRhTrexV1DinosaursIdPut(ctx, "-invalid").Dinosaur(openapi.Dinosaur{Species: "Apatosaurus"}).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+}
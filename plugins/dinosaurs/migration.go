@@ -23,3 +23,33 @@ func migration() *gormigrate.Migration {
 		},
 	}
 }
+
+func labelsMigration() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610190800",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(db.AddLabelsSQL("dinosaurs")).Error; err != nil {
+				return err
+			}
+			return tx.Exec(db.LabelsIndexSQL("dinosaurs")).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec("DROP INDEX IF EXISTS " + db.LabelsIndexName("dinosaurs")).Error; err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn("dinosaurs", db.LabelsColumn)
+		},
+	}
+}
+
+func speciesTextSearchMigration() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610190730",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec(db.TextSearchIndexSQL("dinosaurs", "species")).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("DROP INDEX IF EXISTS " + db.TextSearchIndexName("dinosaurs", "species")).Error
+		},
+	}
+}
@@ -40,6 +40,10 @@ func (d *dinosaurDaoMock) Delete(ctx context.Context, id string) error {
 	return errors.NotImplemented("Dinosaur").AsError()
 }
 
+func (d *dinosaurDaoMock) Restore(ctx context.Context, id string) (*Dinosaur, error) {
+	return nil, errors.NotImplemented("Dinosaur").AsError()
+}
+
 func (d *dinosaurDaoMock) FindByIDs(ctx context.Context, ids []string) (DinosaurList, error) {
 	return nil, errors.NotImplemented("Dinosaur").AsError()
 }
@@ -7,6 +7,7 @@ import (
 
 type Dinosaur struct {
 	api.Meta
+	api.Labeled
 	Species string
 }
 
@@ -22,10 +23,14 @@ func (l DinosaurList) Index() DinosaurIndex {
 }
 
 func (d *Dinosaur) BeforeCreate(tx *gorm.DB) error {
-	d.ID = api.NewID()
+	// the id is given by the client when it is created with a PUT request
+	if d.ID == "" {
+		d.ID = api.NewID()
+	}
 	return nil
 }
 
 type DinosaurPatchRequest struct {
-	Species *string `json:"species,omitempty"`
+	Species *string            `json:"species,omitempty"`
+	Labels  *map[string]string `json:"labels,omitempty"`
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/openshift-online/rh-trex-ai/pkg/environments"
 	"github.com/openshift-online/rh-trex-ai/pkg/registry"
 	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
 	"github.com/openshift-online/rh-trex-ai/plugins/events"
 	"github.com/openshift-online/rh-trex-ai/plugins/generic"
 )
@@ -46,18 +47,27 @@ func init() {
 
 	pkgserver.RegisterRoutes("dinosaurs", func(apiV1Router *mux.Router, services pkgserver.ServicesInterface, authMiddleware auth.JWTMiddleware, authzMiddleware auth.AuthorizationMiddleware) {
 		envServices := services.(*environments.Services)
-		dinosaurHandler := NewDinosaurHandler(Service(envServices), generic.Service(envServices))
+		dinosaurHandler := NewDinosaurHandler(Service(envServices), generic.Service(envServices),
+			pkgserver.NewIdempotencyKeyService(environments.Environment()), authzMiddleware,
+			environments.Environment().Config.Server.CreatesOnPut("Dinosaur"))
 
 		dinosaursRouter := apiV1Router.PathPrefix("/dinosaurs").Subrouter()
-		dinosaursRouter.HandleFunc("", dinosaurHandler.List).Methods(http.MethodGet)
-		dinosaursRouter.HandleFunc("/{id}", dinosaurHandler.Get).Methods(http.MethodGet)
-		dinosaursRouter.HandleFunc("", dinosaurHandler.Create).Methods(http.MethodPost)
-		dinosaursRouter.HandleFunc("/{id}", dinosaurHandler.Patch).Methods(http.MethodPatch)
-		dinosaursRouter.HandleFunc("/{id}", dinosaurHandler.Delete).Methods(http.MethodDelete)
+		dinosaursRouter.HandleFunc("", authzMiddleware.AuthorizeApi(auth.ActionList, "Dinosaur", dinosaurHandler.List)).Methods(http.MethodGet)
+		dinosaursRouter.HandleFunc("/aggregate", authzMiddleware.AuthorizeApi(auth.ActionList, "Dinosaur", dinosaurHandler.Aggregate)).Methods(http.MethodGet)
+		// the bulk handler authorizes each operation for its own action
+		dinosaursRouter.HandleFunc("/bulk", dinosaurHandler.Bulk).Methods(http.MethodPost)
+		dinosaursRouter.HandleFunc("/{id}", authzMiddleware.AuthorizeApi(auth.ActionGet, "Dinosaur", dinosaurHandler.Get)).Methods(http.MethodGet)
+		dinosaursRouter.HandleFunc("", authzMiddleware.AuthorizeApi(auth.ActionCreate, "Dinosaur", dinosaurHandler.Create)).Methods(http.MethodPost)
+		dinosaursRouter.HandleFunc("/{id}", authzMiddleware.AuthorizeApi(auth.ActionUpdate, "Dinosaur", dinosaurHandler.Patch)).Methods(http.MethodPatch)
+		// a PUT creating the resource, see --create-on-put-kinds, is authorized for create by the handler
+		dinosaursRouter.HandleFunc("/{id}", authzMiddleware.AuthorizeApi(auth.ActionUpdate, "Dinosaur", dinosaurHandler.Put)).Methods(http.MethodPut)
+		dinosaursRouter.HandleFunc("/{id}", authzMiddleware.AuthorizeApi(auth.ActionDelete, "Dinosaur", dinosaurHandler.Delete)).Methods(http.MethodDelete)
+		dinosaursRouter.HandleFunc("/{id}/restore", authzMiddleware.AuthorizeApi(auth.ActionUpdate, "Dinosaur", dinosaurHandler.Restore)).Methods(http.MethodPost)
 		dinosaursRouter.Use(authMiddleware.AuthenticateAccountJWT)
-		dinosaursRouter.Use(authzMiddleware.AuthorizeApi)
 	})
 
+	pkgserver.RegisterPurgeJob("Dinosaurs", &Dinosaur{})
+
 	pkgserver.RegisterController("Dinosaurs", func(manager *controllers.KindControllerManager, services pkgserver.ServicesInterface) {
 		dinoServices := Service(services.(*environments.Services))
 
@@ -76,5 +86,14 @@ func init() {
 	presenters.RegisterKind(Dinosaur{}, "Dinosaur")
 	presenters.RegisterKind(&Dinosaur{}, "Dinosaur")
 
+	services.RegisterTextSearchFields("Dinosaur", "species")
+	services.RegisterFieldAllowlist("Dinosaur", services.FieldAllowlist{
+		Searchable:  []string{"id", "species", "created_at", "updated_at", "deleted_at"},
+		Sortable:    []string{"id", "species", "created_at", "updated_at", "deleted_at"},
+		Projectable: []string{"species", "labels", "created_at", "updated_at", "deleted_at"},
+	})
+
 	db.RegisterMigration(migration())
+	db.RegisterMigration(speciesTextSearchMigration())
+	db.RegisterMigration(labelsMigration())
 }
@@ -12,18 +12,26 @@ func ConvertDinosaur(dinosaur openapi.Dinosaur) *Dinosaur {
 		Meta: api.Meta{
 			ID: util.NilToEmptyString(dinosaur.Id),
 		},
+		Labeled: api.Labeled{
+			Labels: api.Labels(util.FromPtr(dinosaur.Labels)),
+		},
 		Species: dinosaur.Species,
 	}
 }
 
 func PresentDinosaur(dinosaur *Dinosaur) openapi.Dinosaur {
 	reference := presenters.PresentReference(dinosaur.ID, dinosaur)
-	return openapi.Dinosaur{
+	presented := openapi.Dinosaur{
 		Id:        reference.Id,
 		Kind:      reference.Kind,
 		Href:      reference.Href,
 		Species:   dinosaur.Species,
+		Labels:    util.ToPtr(map[string]string(dinosaur.Labels)),
 		CreatedAt: openapi.PtrTime(dinosaur.CreatedAt),
 		UpdatedAt: openapi.PtrTime(dinosaur.UpdatedAt),
 	}
+	if dinosaur.DeletedAt.Valid {
+		presented.DeletedAt = openapi.PtrTime(dinosaur.DeletedAt.Time)
+	}
+	return presented
 }
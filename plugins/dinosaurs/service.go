@@ -2,6 +2,10 @@ package dinosaurs
 
 import (
 	"context"
+	e "errors"
+	"reflect"
+
+	"gorm.io/gorm"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
@@ -21,7 +25,9 @@ type DinosaurService interface {
 	Get(ctx context.Context, id string) (*Dinosaur, *errors.ServiceError)
 	Create(ctx context.Context, dinosaur *Dinosaur) (*Dinosaur, *errors.ServiceError)
 	Replace(ctx context.Context, dinosaur *Dinosaur) (*Dinosaur, *errors.ServiceError)
+	Patch(ctx context.Context, id string, patch func(found *Dinosaur) (*Dinosaur, *errors.ServiceError)) (*Dinosaur, *errors.ServiceError)
 	Delete(ctx context.Context, id string) *errors.ServiceError
+	Restore(ctx context.Context, id string) (*Dinosaur, *errors.ServiceError)
 	All(ctx context.Context) (DinosaurList, *errors.ServiceError)
 
 	FindBySpecies(ctx context.Context, species string) (DinosaurList, *errors.ServiceError)
@@ -93,18 +99,26 @@ func (s *sqlDinosaurService) Create(ctx context.Context, dinosaur *Dinosaur) (*D
 }
 
 func (s *sqlDinosaurService) Replace(ctx context.Context, dinosaur *Dinosaur) (*Dinosaur, *errors.ServiceError) {
+	return s.Patch(ctx, dinosaur.ID, func(*Dinosaur) (*Dinosaur, *errors.ServiceError) {
+		return dinosaur, nil
+	})
+}
+
+// Patch replaces the dinosaur with the one patch returns, given the dinosaur read under the advisory lock
+// of the dinosaur, so that concurrent patches to different fields don't overwrite each other
+func (s *sqlDinosaurService) Patch(ctx context.Context, id string, patch func(found *Dinosaur) (*Dinosaur, *errors.ServiceError)) (*Dinosaur, *errors.ServiceError) {
 	if !DisableAdvisoryLock {
 		if UseBlockingAdvisoryLock {
-			lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, dinosaur.ID, dinosaursLockType)
+			lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, id, dinosaursLockType)
 			if err != nil {
-				return nil, errors.DatabaseAdvisoryLock(err)
+				return nil, errors.DatabaseAdvisoryLock(err).WithStack()
 			}
 			defer s.lockFactory.Unlock(ctx, lockOwnerID)
 
 		} else {
-			lockOwnerID, locked, err := s.lockFactory.NewNonBlockingLock(ctx, dinosaur.ID, dinosaursLockType)
+			lockOwnerID, locked, err := s.lockFactory.NewNonBlockingLock(ctx, id, dinosaursLockType)
 			if err != nil {
-				return nil, errors.DatabaseAdvisoryLock(err)
+				return nil, errors.DatabaseAdvisoryLock(err).WithStack()
 			}
 			if !locked {
 				return nil, services.HandleUpdateError("Dinosaur", errors.New(errors.ErrorConflict, "row locked"))
@@ -113,18 +127,26 @@ func (s *sqlDinosaurService) Replace(ctx context.Context, dinosaur *Dinosaur) (*
 		}
 	}
 
-	found, err := s.dinosaurDao.Get(ctx, dinosaur.ID)
+	found, err := s.dinosaurDao.Get(ctx, id)
 	if err != nil {
-		return nil, services.HandleGetError("Dinosaur", "id", dinosaur.ID, err)
+		return nil, services.HandleGetError("Dinosaur", "id", id, err)
+	}
+	dinosaur, serviceErr := patch(found)
+	if serviceErr != nil {
+		return nil, serviceErr
 	}
 
-
-	if found.Species == dinosaur.Species {
+	// every field but the id and the timestamps is replaced
+	replacement := *dinosaur
+	replacement.Meta = found.Meta
+	if replacement.Labels == nil {
+		replacement.Labels = api.Labels{}
+	}
+	if reflect.DeepEqual(*found, replacement) {
 		return found, nil
 	}
 
-	found.Species = dinosaur.Species
-	updated, err := s.dinosaurDao.Replace(ctx, found)
+	updated, err := s.dinosaurDao.Replace(ctx, &replacement)
 	if err != nil {
 		return nil, services.HandleUpdateError("Dinosaur", err)
 	}
@@ -142,7 +164,7 @@ func (s *sqlDinosaurService) Replace(ctx context.Context, dinosaur *Dinosaur) (*
 
 func (s *sqlDinosaurService) Delete(ctx context.Context, id string) *errors.ServiceError {
 	if err := s.dinosaurDao.Delete(ctx, id); err != nil {
-		return services.HandleDeleteError("Dinosaur", errors.GeneralError("Unable to delete dinosaur: %s", err))
+		return services.HandleDeleteError("Dinosaur", err)
 	}
 
 	_, err := s.events.Create(ctx, &api.Event{
@@ -157,10 +179,34 @@ func (s *sqlDinosaurService) Delete(ctx context.Context, id string) *errors.Serv
 	return nil
 }
 
+// Restore undeletes a soft-deleted dinosaur, the controllers see it created again
+func (s *sqlDinosaurService) Restore(ctx context.Context, id string) (*Dinosaur, *errors.ServiceError) {
+	dinosaur, err := s.dinosaurDao.Restore(ctx, id)
+	if err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			if _, getErr := s.dinosaurDao.Get(ctx, id); getErr == nil {
+				return nil, errors.Conflict("Dinosaur with id='%s' is not deleted", id)
+			}
+		}
+		return nil, services.HandleGetError("Dinosaur", "id", id, err)
+	}
+
+	_, eErr := s.events.Create(ctx, &api.Event{
+		Source:    "Dinosaurs",
+		SourceID:  dinosaur.ID,
+		EventType: api.CreateEventType,
+	})
+	if eErr != nil {
+		return nil, services.HandleCreateError("Dinosaur", eErr)
+	}
+
+	return dinosaur, nil
+}
+
 func (s *sqlDinosaurService) FindByIDs(ctx context.Context, ids []string) (DinosaurList, *errors.ServiceError) {
 	dinosaurs, err := s.dinosaurDao.FindByIDs(ctx, ids)
 	if err != nil {
-		return nil, errors.GeneralError("Unable to get all dinosaurs: %s", err)
+		return nil, errors.GeneralError("Unable to get all dinosaurs").WithCause(err).WithStack()
 	}
 	return dinosaurs, nil
 }
@@ -176,7 +222,7 @@ func (s *sqlDinosaurService) FindBySpecies(ctx context.Context, species string)
 func (s *sqlDinosaurService) All(ctx context.Context) (DinosaurList, *errors.ServiceError) {
 	dinosaurs, err := s.dinosaurDao.All(ctx)
 	if err != nil {
-		return nil, errors.GeneralError("Unable to get all dinosaurs: %s", err)
+		return nil, errors.GeneralError("Unable to get all dinosaurs").WithCause(err).WithStack()
 	}
 	return dinosaurs, nil
 }
@@ -2,8 +2,11 @@ package dinosaurs
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"testing"
 
+	"github.com/jackc/pgconn"
 	gm "github.com/onsi/gomega"
 
 	dbmocks "github.com/openshift-online/rh-trex-ai/pkg/db/mocks"
@@ -47,3 +50,29 @@ func TestDinosaurFindBySpecies(t *testing.T) {
 	gm.Expect(err).To(gm.BeNil())
 	gm.Expect(len(breviceratops)).To(gm.Equal(1))
 }
+
+// referencedDinosaurDao fails the deletes as Postgres does for the dinosaurs still referenced
+type referencedDinosaurDao struct {
+	*dinosaurDaoMock
+}
+
+func (d *referencedDinosaurDao) Delete(ctx context.Context, id string) error {
+	return fmt.Errorf("delete: %w", &pgconn.PgError{
+		Code:           "23503",
+		Message:        `update or delete on table "dinosaurs" violates foreign key constraint "fk_nests_dinosaur" on table "nests"`,
+		Detail:         fmt.Sprintf(`Key (id)=(%s) is still referenced from table "nests".`, id),
+		ConstraintName: "fk_nests_dinosaur",
+	})
+}
+
+func TestDinosaurDeleteReferenced(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	events := services.NewEventService(daomocks.NewEventDao())
+	dinoService := NewDinosaurService(dbmocks.NewMockAdvisoryLockFactory(), &referencedDinosaurDao{NewMockDinosaurDao()}, events)
+
+	err := dinoService.Delete(context.Background(), "1")
+	gm.Expect(err).NotTo(gm.BeNil())
+	gm.Expect(err.HttpCode).To(gm.Equal(http.StatusConflict))
+	gm.Expect(err.Reason).To(gm.Equal("Dinosaur is still referenced by nests"))
+}
@@ -287,23 +287,26 @@ func parseFields(fieldsStr string) ([]Field, error) {
 	fieldPairs := strings.Split(fieldsStr, ",")
 	for _, pair := range fieldPairs {
 		parts := strings.Split(strings.TrimSpace(pair), ":")
-		if len(parts) < 2 || len(parts) > 3 {
-			return nil, fmt.Errorf("invalid field format: %s (expected name:type or name:type:required)", pair)
+		if len(parts) < 2 || len(parts) > 4 {
+			return nil, fmt.Errorf("invalid field format: %s (expected name:type, name:type:required or name:type:required:searchable)", pair)
 		}
 
 		name := strings.TrimSpace(parts[0])
 		fieldType := strings.TrimSpace(parts[1])
 		nullable := true // Default to nullable
+		searchable := false
 
-		// Check for :required or :optional suffix
-		if len(parts) == 3 {
-			modifier := strings.TrimSpace(parts[2])
+		// Check for :required, :optional and :searchable suffixes
+		for _, modifier := range parts[2:] {
+			modifier = strings.TrimSpace(modifier)
 			if modifier == "required" {
 				nullable = false
 			} else if modifier == "optional" {
 				nullable = true
+			} else if modifier == "searchable" {
+				searchable = true
 			} else {
-				return nil, fmt.Errorf("invalid field modifier: %s (expected 'required' or 'optional')", modifier)
+				return nil, fmt.Errorf("invalid field modifier: %s (expected 'required', 'optional' or 'searchable')", modifier)
 			}
 		}
 
@@ -311,6 +314,13 @@ func parseFields(fieldsStr string) ([]Field, error) {
 		if err != nil {
 			return nil, err
 		}
+		if searchable {
+			// full-text search runs on text columns only
+			if fieldType != "string" {
+				return nil, fmt.Errorf("invalid field modifier: %s (only string fields are searchable)", pair)
+			}
+			field.Searchable = true
+		}
 
 		fields = append(fields, field)
 	}
@@ -402,6 +412,7 @@ type Field struct {
 	Nullable           bool
 	PointerType        string
 	NeedsIntConversion bool
+	Searchable         bool
 }
 
 type myWriter struct {
@@ -418,6 +429,17 @@ type myWriter struct {
 	Fields              []Field
 }
 
+// SearchableFields are the fields supporting full-text search
+func (w myWriter) SearchableFields() []Field {
+	var searchable []Field
+	for _, field := range w.Fields {
+		if field.Searchable {
+			searchable = append(searchable, field)
+		}
+	}
+	return searchable
+}
+
 func modifyOpenapi(mainPath string, kindPath string) {
 	endpointStrings := readBetweenLines(kindPath, openapiEndpointStart, openapiEndpointEnd)
 	kindFileName := strings.Split(kindPath, "/")[1]
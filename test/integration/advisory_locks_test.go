@@ -0,0 +1,38 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/test"
+)
+
+// TestAdvisoryLockInTransaction checks that a lock obtained in a transaction is held until the transaction
+// ends, not until it is unlocked, so that no other request reads the rows it guards before they are committed
+func TestAdvisoryLockInTransaction(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	lockFactory := db.NewAdvisoryLockFactory(h.Env().Database.SessionFactory)
+	ctx := context.Background()
+	tryLock := func() bool {
+		owner, acquired, err := lockFactory.NewNonBlockingLock(ctx, "a-id", "test")
+		Expect(err).NotTo(HaveOccurred())
+		lockFactory.Unlock(ctx, owner)
+		return acquired
+	}
+
+	err := db.Transact(ctx, h.Env().Database.SessionFactory, func(ctx context.Context) error {
+		owner, err := lockFactory.NewAdvisoryLock(ctx, "a-id", "test")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tryLock()).To(BeFalse())
+
+		lockFactory.Unlock(ctx, owner)
+		Expect(tryLock()).To(BeFalse(), "the lock is released before the transaction ends")
+		return nil
+	})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(tryLock()).To(BeTrue())
+}
@@ -0,0 +1,35 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/test"
+)
+
+func TestErrorsGet(t *testing.T) {
+	_, client := test.RegisterIntegration(t)
+	ctx := context.Background()
+
+	// the catalog is public, like the specification
+	list, resp, err := client.DefaultAPI.ApiRhTrexV1ErrorsGet(ctx).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error listing errors: %v", err)
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	Expect(list.Kind).To(Equal("ErrorList"))
+	Expect(list.Items).To(HaveLen(len(errors.Errors())))
+
+	// the href of the error responses leads to the entry of their code
+	item, resp, err := client.DefaultAPI.ApiRhTrexV1ErrorsIdGet(ctx, "7").Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error getting error: %v", err)
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	Expect(item.GetHref()).To(Equal("/api/rh-trex/v1/errors/7"))
+	Expect(item.GetReason()).To(Equal("Resource not found"))
+
+	_, resp, err = client.DefaultAPI.ApiRhTrexV1ErrorsIdGet(ctx, "91823719").Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+}
@@ -0,0 +1,97 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/test"
+)
+
+type searchTeam struct {
+	api.Meta
+	Name string
+}
+
+func (searchTeam) TableName() string { return "search_teams" }
+
+type searchOwner struct {
+	api.Meta
+	Name   string
+	TeamID string
+	Team   *searchTeam
+}
+
+func (searchOwner) TableName() string { return "search_owners" }
+
+type searchPet struct {
+	api.Meta
+	Species string
+	OwnerID string
+	Owner   *searchOwner
+	// a second relation onto the owners table, only an alias can tell the joins apart
+	SitterID string
+	Sitter   *searchOwner
+}
+
+func (searchPet) TableName() string { return "search_pets" }
+
+func TestSearchRelatedResources(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	g2 := h.DBFactory.New(context.Background())
+	Expect(g2.AutoMigrate(&searchTeam{}, &searchOwner{}, &searchPet{})).To(Succeed())
+
+	red := &searchTeam{Meta: api.Meta{ID: h.NewID()}, Name: "red"}
+	blue := &searchTeam{Meta: api.Meta{ID: h.NewID()}, Name: "blue"}
+	Expect(g2.Create(red).Error).NotTo(HaveOccurred())
+	Expect(g2.Create(blue).Error).NotTo(HaveOccurred())
+
+	alice := &searchOwner{Meta: api.Meta{ID: h.NewID()}, Name: "alice", TeamID: red.ID}
+	bob := &searchOwner{Meta: api.Meta{ID: h.NewID()}, Name: "bob", TeamID: blue.ID}
+	Expect(g2.Create(alice).Error).NotTo(HaveOccurred())
+	Expect(g2.Create(bob).Error).NotTo(HaveOccurred())
+
+	pets := []*searchPet{
+		{Meta: api.Meta{ID: h.NewID()}, Species: "dog", OwnerID: alice.ID, SitterID: bob.ID},
+		{Meta: api.Meta{ID: h.NewID()}, Species: "cat", OwnerID: alice.ID, SitterID: alice.ID},
+		{Meta: api.Meta{ID: h.NewID()}, Species: "fish", OwnerID: bob.ID, SitterID: alice.ID},
+	}
+	for _, pet := range pets {
+		Expect(g2.Create(pet).Error).NotTo(HaveOccurred())
+	}
+
+	genericService := services.NewGenericService(dao.NewGenericDao(&h.DBFactory))
+	list := func(search string, orderBy ...string) []string {
+		var found []searchPet
+		args := &services.ListArguments{Page: 1, Size: 100, Search: search, OrderBy: orderBy}
+		_, serviceErr := genericService.List(context.Background(), "", args, &found)
+		Expect(serviceErr).NotTo(HaveOccurred())
+		species := []string{}
+		for _, pet := range found {
+			species = append(species, pet.Species)
+		}
+		return species
+	}
+
+	// two hops: pet -> owner -> team
+	Expect(list("owner.team.name = 'red'")).To(ConsistOf("dog", "cat"))
+	Expect(list("owner.team.name = 'blue'")).To(ConsistOf("fish"))
+
+	// both relations onto the owners table are joined side by side
+	Expect(list("owner.name = 'alice' and sitter.team.name = 'blue'")).To(ConsistOf("dog"))
+	Expect(list("owner.team.name = 'red' and sitter.team.name = 'red'")).To(ConsistOf("cat"))
+
+	// order by related fields, the related tables are joined without any search
+	Expect(list("", "owner.team.name asc", "species desc")).To(Equal([]string{"fish", "dog", "cat"}))
+	Expect(list("species <> 'cat'", "sitter.name desc")).To(Equal([]string{"dog", "fish"}))
+
+	var found []searchPet
+	_, serviceErr := genericService.List(context.Background(), "", &services.ListArguments{Page: 1, Size: 100, Search: "owner.club.name = 'x'"}, &found)
+	Expect(serviceErr).To(HaveOccurred())
+	Expect(serviceErr.Reason).To(Equal("owner.club is not a related resource of searchPet"))
+}
@@ -1,11 +1,13 @@
 package test
 
 import (
+	"strings"
 	"testing"
 
 	gm "github.com/onsi/gomega"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
 )
 
 // RegisterIntegration Register a test
@@ -17,6 +19,13 @@ func RegisterIntegration(t *testing.T) (*Helper, *openapi.APIClient) {
 	helper := NewHelper(t)
 	// Reset the database to a seeded blank state
 	helper.DBFactory.ResetDB()
+	// Fail the test if the API server sends responses not matching the OpenAPI specification
+	pkgserver.ResponseViolations.Take()
+	t.Cleanup(func() {
+		if violations := pkgserver.ResponseViolations.Take(); len(violations) > 0 {
+			t.Errorf("Responses not matching the OpenAPI specification:\n%s", strings.Join(violations, "\n"))
+		}
+	})
 	// Create an api client
 	client := helper.NewApiClient()
 